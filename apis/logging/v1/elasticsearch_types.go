@@ -89,6 +89,68 @@ type ElasticsearchSpec struct {
 	// +nullable
 	// +optional
 	IndexManagement *IndexManagementSpec `json:"indexManagement"`
+
+	// Maximum number of data nodes restarted together during rolling updates and restarts.
+	// Data nodes are grouped by zone when available and the operator never takes down
+	// more nodes than the replica count of the cluster can tolerate. Defaults to one node at a time.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxUnavailable int32 `json:"maxUnavailable,omitempty"`
+
+	// Dynamic cluster settings applied as persistent settings through the cluster settings API.
+	// Settings are applied without restarting any node and drifted values are reverted.
+	// Settings managed by the operator itself (e.g. shard allocation) are rejected.
+	//
+	// +optional
+	ClusterSettings map[string]string `json:"clusterSettings,omitempty"`
+
+	// Search and indexing slow log thresholds applied to the managed index templates and live indices
+	//
+	// +optional
+	SlowLog *ElasticsearchSlowLogSpec `json:"slowLog,omitempty"`
+
+	// Log levels of Elasticsearch loggers changed at runtime without restarting nodes
+	//
+	// +optional
+	Logging *ElasticsearchLoggingSpec `json:"logging,omitempty"`
+
+	// Opt-in deletion of low-priority indices when a node exceeds the flood stage disk watermark
+	//
+	// +optional
+	EmergencyRetention *ElasticsearchEmergencyRetentionSpec `json:"emergencyRetention,omitempty"`
+
+	// Restricts the ingress traffic to the Elasticsearch pods
+	//
+	// +optional
+	NetworkPolicy *ElasticsearchNetworkPolicySpec `json:"networkPolicy,omitempty"`
+
+	// Exposes the Elasticsearch REST API outside of the cluster
+	//
+	// +optional
+	Exposure *ElasticsearchExposureSpec `json:"exposure,omitempty"`
+
+	// Other clusters searchable from this cluster by cross-cluster search. The transport layer
+	// trusts the CAs of the remote clusters. Trusting other CAs than the one of the cluster switches
+	// the transport layer from the PKCS12 truststore to a PEM bundle, any change of the bundle,
+	// including the rotation of a remote CA, is applied by a rolling restart of the nodes.
+	//
+	// +optional
+	RemoteClusters []ElasticsearchRemoteCluster `json:"remoteClusters,omitempty"`
+
+	// Elasticsearch CRs allowed to use this cluster as remote cluster. Only the CAs of the clusters
+	// listed here and of the remote clusters are trusted by the transport layer, a cluster referencing
+	// this one as remote cluster without being listed cannot connect. Changes restart the nodes as
+	// for the remote clusters.
+	//
+	// +optional
+	TrustedClusters []ElasticsearchReference `json:"trustedClusters,omitempty"`
+
+	// Namespaces of the Kibana CRs allowed to reference this cluster from another namespace.
+	// The operator issues these Kibana instances a client certificate signed by the CA of the cluster.
+	//
+	// +optional
+	AllowedKibanaNamespaces []string `json:"allowedKibanaNamespaces,omitempty"`
 }
 
 // ElasticsearchStatus defines the observed state of Elasticsearch
@@ -110,6 +172,18 @@ type ElasticsearchStatus struct {
 	Conditions ClusterConditions `json:"conditions,omitempty"`
 	// +optional
 	IndexManagementStatus *IndexManagementStatus `json:"indexManagement,omitempty"`
+	// Persistent cluster settings last applied from spec.clusterSettings
+	//
+	// +optional
+	ClusterSettings map[string]string `json:"clusterSettings,omitempty"`
+	// The logger levels last applied from spec.logging
+	//
+	// +optional
+	Logging *ElasticsearchLoggingStatus `json:"logging,omitempty"`
+	// The connectivity of the remote clusters configured from spec.remoteClusters
+	//
+	// +optional
+	RemoteClusters []ElasticsearchRemoteClusterStatus `json:"remoteClusters,omitempty"`
 }
 
 type ClusterHealth struct {
@@ -176,6 +250,77 @@ type ElasticsearchNode struct {
 
 	// The resource requirements for the Elasticsearch proxy
 	ProxyResources corev1.ResourceRequirements `json:"proxyResources,omitempty"`
+
+	// The JVM settings for the Elasticsearch node, overriding the ones of the common spec
+	//
+	// +nullable
+	// +optional
+	JVM *ElasticsearchJVMSpec `json:"jvm,omitempty"`
+
+	// Additional elasticsearch.yml settings for the node, merged over the ones of the common spec.
+	// Settings owned by the operator (e.g. discovery, paths and security) are not allowed.
+	//
+	// +nullable
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// Labels added to the pods of the node, merged over the ones of the common spec.
+	// Labels set by the operator take precedence.
+	//
+	// +nullable
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// Annotations added to the pods of the node, merged over the ones of the common spec
+	//
+	// +nullable
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// The priority class of the pods of the node, overriding the one of the common spec
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Scheduling constraints of the pods of the node, overriding the ones of the common spec.
+	// The operator's anti-affinity spreading the nodes across hosts is always added.
+	//
+	// +nullable
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Topology spread constraints of the pods of the node, overriding the ones of the common spec
+	//
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Additional volumes of the pods of the node, merged over the ones of the common spec.
+	// Names of the volumes managed by the operator are not allowed.
+	//
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// Additional volume mounts of the elasticsearch container, merged over the ones of the common spec
+	//
+	// +optional
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// Init containers run before the Elasticsearch node starts, merged over the ones of the common spec
+	//
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// Additional containers run along the Elasticsearch node, merged over the ones of the common spec.
+	// Names of the containers managed by the operator are not allowed.
+	//
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
 }
 
 // ElasticsearchNodeSpec represents configuration of an individual Elasticsearch node
@@ -204,6 +349,325 @@ type ElasticsearchNodeSpec struct {
 	// +nullable
 	// +optional
 	ProxyResources corev1.ResourceRequirements `json:"proxyResources,omitempty"`
+
+	// The JVM settings for the Elasticsearch nodes
+	//
+	// +nullable
+	// +optional
+	JVM *ElasticsearchJVMSpec `json:"jvm,omitempty"`
+
+	// Additional elasticsearch.yml settings for the Elasticsearch nodes (e.g. thread_pool.write.queue_size).
+	// Settings owned by the operator (e.g. discovery, paths and security) are not allowed.
+	//
+	// +nullable
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// Labels added to the pods of the Elasticsearch nodes.
+	// Labels set by the operator take precedence.
+	//
+	// +nullable
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// Annotations added to the pods of the Elasticsearch nodes
+	//
+	// +nullable
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// The priority class of the pods of the Elasticsearch nodes
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Scheduling constraints of the pods of the Elasticsearch nodes.
+	// The operator's anti-affinity spreading the nodes across hosts is always added.
+	//
+	// +nullable
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Topology spread constraints of the pods of the Elasticsearch nodes
+	//
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Additional volumes of the pods of the Elasticsearch nodes.
+	// Names of the volumes managed by the operator are not allowed.
+	//
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// Additional volume mounts of the elasticsearch container
+	//
+	// +optional
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// Init containers run before the Elasticsearch nodes start
+	//
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// Additional containers run along the Elasticsearch nodes.
+	// Names of the containers managed by the operator are not allowed.
+	//
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+}
+
+// ElasticsearchJVMSpec defines the JVM settings of an Elasticsearch node
+type ElasticsearchJVMSpec struct {
+	// The heap size as a percentage of the memory limit of the Elasticsearch container.
+	// Defaults to 50 percent.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=90
+	// +optional
+	HeapPercent int32 `json:"heapPercent,omitempty"`
+
+	// The absolute heap size (e.g. 31Gi), taking precedence over heapPercent.
+	// Heaps are rounded up to whole mebibytes and must be at least 256Mi.
+	//
+	// +nullable
+	// +optional
+	HeapSize *resource.Quantity `json:"heapSize,omitempty"`
+
+	// Additional options passed to the JVM. Heap options (-Xms, -Xmx) are not allowed.
+	//
+	// +optional
+	Options []string `json:"options,omitempty"`
+}
+
+// ElasticsearchSlowLogSpec defines the slow log thresholds of the search and indexing phases
+type ElasticsearchSlowLogSpec struct {
+	// Thresholds for the query phase of searches
+	//
+	// +optional
+	SearchQuery *SlowLogThresholds `json:"searchQuery,omitempty"`
+
+	// Thresholds for the fetch phase of searches
+	//
+	// +optional
+	SearchFetch *SlowLogThresholds `json:"searchFetch,omitempty"`
+
+	// Thresholds for indexing documents
+	//
+	// +optional
+	Indexing *SlowLogThresholds `json:"indexing,omitempty"`
+
+	// Write the slow logs to the console instead of the slow log files so
+	// they are picked up by the cluster's log collector
+	//
+	// +optional
+	Console bool `json:"console,omitempty"`
+}
+
+// ElasticsearchLoggingSpec defines the log levels of Elasticsearch loggers
+type ElasticsearchLoggingSpec struct {
+	// Log level per logger name, e.g. org.elasticsearch.discovery: debug
+	//
+	// +optional
+	Loggers map[string]LogLevel `json:"loggers,omitempty"`
+
+	// Time after which debug and trace levels are reverted to the default level, e.g. 1h.
+	// Debug and trace levels are kept until the loggers change when not set.
+	//
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// ElasticsearchEmergencyRetentionSpec defines the indices deleted when a node exceeds the flood stage watermark
+type ElasticsearchEmergencyRetentionSpec struct {
+	// Low-priority aliases in the order their indices are deleted. The oldest non-write
+	// index is deleted, one per reconciliation, until all nodes are below the high watermark.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Aliases []string `json:"aliases"`
+}
+
+// ElasticsearchNetworkPolicySpec defines the clients admitted to the Elasticsearch pods
+type ElasticsearchNetworkPolicySpec struct {
+	// Enforce a network policy admitting only the operator, the pods of the cluster, Kibana, the index
+	// management jobs, the clients listed below and metrics scraping from the monitoring namespace
+	//
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Additional clients admitted to the Elasticsearch REST API
+	//
+	// +optional
+	Clients []ElasticsearchNetworkPolicyClient `json:"clients,omitempty"`
+}
+
+// ElasticsearchNetworkPolicyClient selects the pods admitted to the Elasticsearch REST API.
+// Pods of the cluster namespace are selected when no namespace selector is set and all pods
+// of the selected namespaces when no pod selector is set.
+type ElasticsearchNetworkPolicyClient struct {
+	// Selects the namespaces of the client pods
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Selects the client pods
+	//
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// ElasticsearchExposureType is the kind of resource exposing the REST API
+//
+// +kubebuilder:validation:Enum=Route;Ingress
+type ElasticsearchExposureType string
+
+const (
+	ExposureTypeRoute   ElasticsearchExposureType = "Route"
+	ExposureTypeIngress ElasticsearchExposureType = "Ingress"
+)
+
+// ElasticsearchExposureSpec defines the Route or the Ingress exposing the REST API
+type ElasticsearchExposureSpec struct {
+	// Kind of resource exposing the REST API. Routes reencrypt the traffic to the cluster
+	// with the CA of the operator as destination CA.
+	//
+	// +kubebuilder:default:=Route
+	// +optional
+	Type ElasticsearchExposureType `json:"type,omitempty"`
+
+	// Host name of the REST API, added to the certificate of the cluster.
+	// Generated by the router for Routes when not set.
+	//
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Name of the IngressClass of the Ingress
+	//
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// Name of the secret with the certificate the Ingress controller presents for the host
+	//
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Annotations of the Route or the Ingress, e.g. to configure the backend protocol of the Ingress controller
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ElasticsearchRemoteCluster defines a cluster searchable from this cluster. Either an
+// Elasticsearch CR or the seeds of an external cluster are referenced.
+type ElasticsearchRemoteCluster struct {
+	// Alias of the remote cluster in cross-cluster searches, e.g. infra:app-*
+	//
+	// +kubebuilder:validation:Pattern=`^[a-z0-9][a-z0-9_-]*$`
+	Name string `json:"name"`
+
+	// Elasticsearch CR of the remote cluster, its CA is trusted for transport. The remote cluster
+	// needs to list this cluster in its trustedClusters to accept the connection.
+	//
+	// +optional
+	ElasticsearchRef *ElasticsearchReference `json:"elasticsearchRef,omitempty"`
+
+	// Transport addresses (host:port) of an external remote cluster
+	//
+	// +optional
+	Seeds []string `json:"seeds,omitempty"`
+
+	// Name of the secret with the CA certificate (key ca.crt) of an external remote cluster
+	//
+	// +optional
+	CASecretName string `json:"caSecretName,omitempty"`
+
+	// Skip the remote cluster in searches while it is unavailable
+	//
+	// +optional
+	SkipUnavailable bool `json:"skipUnavailable,omitempty"`
+}
+
+// ElasticsearchReference references an Elasticsearch CR
+type ElasticsearchReference struct {
+	// Name of the Elasticsearch CR
+	Name string `json:"name"`
+
+	// Namespace of the Elasticsearch CR, defaults to the namespace of the referencing resource
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ElasticsearchRemoteClusterStatus defines the observed connectivity of a remote cluster
+type ElasticsearchRemoteClusterStatus struct {
+	// Alias of the remote cluster
+	Name string `json:"name"`
+
+	// Transport addresses configured for the remote cluster
+	//
+	// +optional
+	Seeds []string `json:"seeds,omitempty"`
+
+	// Whether the cluster is connected to the remote cluster
+	Connected bool `json:"connected"`
+
+	// Number of remote nodes the cluster is connected to
+	//
+	// +optional
+	NumNodesConnected int32 `json:"numNodesConnected,omitempty"`
+}
+
+// LogLevel is the level of an Elasticsearch logger
+//
+// +kubebuilder:validation:Enum=trace;debug;info;warn;error
+type LogLevel string
+
+const (
+	LogLevelTrace LogLevel = "trace"
+	LogLevelDebug LogLevel = "debug"
+	LogLevelInfo  LogLevel = "info"
+	LogLevelWarn  LogLevel = "warn"
+	LogLevelError LogLevel = "error"
+)
+
+// ElasticsearchLoggingStatus defines the observed logger levels
+type ElasticsearchLoggingStatus struct {
+	// The logger levels applied from the spec
+	//
+	// +optional
+	Loggers map[string]LogLevel `json:"loggers,omitempty"`
+
+	// Time at which debug and trace levels are reverted
+	//
+	// +nullable
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// SlowLogThreshold is the time after which an operation is logged like 500ms or 10s.
+// A threshold of -1 disables the log level.
+//
+// +kubebuilder:validation:Pattern:="^(-1|[0-9]+(nanos|micros|ms|s|m|h|d))$"
+type SlowLogThreshold string
+
+// SlowLogThresholds defines the slow log threshold per log level
+type SlowLogThresholds struct {
+	// +optional
+	Warn SlowLogThreshold `json:"warn,omitempty"`
+
+	// +optional
+	Info SlowLogThreshold `json:"info,omitempty"`
+
+	// +optional
+	Debug SlowLogThreshold `json:"debug,omitempty"`
+
+	// +optional
+	Trace SlowLogThreshold `json:"trace,omitempty"`
 }
 
 type ElasticsearchStorageSpec struct {
@@ -343,4 +807,9 @@ const (
 	StorageClassName         ClusterConditionType = "StorageClassNameChangeIgnored"
 	StorageSize              ClusterConditionType = "StorageSizeChangeIgnored"
 	StorageStructure         ClusterConditionType = "StorageStructureChangeIgnored"
+	InvalidJVMSettings       ClusterConditionType = "InvalidJVMSettings"
+	InvalidConfig            ClusterConditionType = "InvalidConfig"
+	ClusterSettingsApplied   ClusterConditionType = "ClusterSettingsApplied"
+	InvalidClusterSettings   ClusterConditionType = "InvalidClusterSettings"
+	InvalidPodExtensions     ClusterConditionType = "InvalidPodExtensions"
 )
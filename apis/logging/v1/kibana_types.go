@@ -1,6 +1,10 @@
 package v1
 
 import (
+	"bytes"
+	"encoding/json"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -25,16 +29,188 @@ type KibanaSpec struct {
 	NodeSelector map[string]string   `json:"nodeSelector,omitempty"`
 	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
 
-	// The desired number of Kibana Pods for the Visualization component
+	// The desired number of Kibana Pods for the Visualization component,
+	// ignored while autoscaling is enabled
 	//
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kibana Size",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
 	Replicas int32 `json:"replicas"`
 
+	// Horizontal autoscaling of the Kibana pods. The autoscaler owns the number of
+	// replicas of the deployment while it is set.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kibana Autoscaling"
+	Autoscaling *KibanaAutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// Specification of the Kibana Proxy component
 	//
 	// +optional
 	ProxySpec `json:"proxy,omitempty"`
+
+	// Reference to the Elasticsearch cluster Kibana connects to. Defaults to the
+	// Elasticsearch CR in the namespace of the Kibana CR when a single one exists,
+	// the reference is required when the namespace holds several ones. A cluster in
+	// another namespace needs to list the namespace of the Kibana CR in its
+	// allowedKibanaNamespaces. Kibana pods in another namespace than the cluster need
+	// to be admitted by the network policy clients of the cluster when its network
+	// policy is enabled.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Elasticsearch Reference"
+	ElasticsearchRef *ElasticsearchReference `json:"elasticsearchRef,omitempty"`
+
+	// Additional kibana.yml settings, e.g. elasticsearch.requestTimeout or server.defaultRoute.
+	// Settings are rendered into a kibana.yml file which Kibana loads on top of the
+	// default one. Settings owned by the operator (e.g. elasticsearch.hosts,
+	// server and security settings) are not allowed.
+	//
+	// +nullable
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// Index patterns created through the Kibana saved objects API once Kibana is rolled out.
+	// Existing index patterns are left untouched.
+	//
+	// +optional
+	IndexPatterns []KibanaIndexPattern `json:"indexPatterns,omitempty"`
+
+	// Title of the index pattern set as default index once Kibana is rolled out
+	//
+	// +optional
+	DefaultIndex string `json:"defaultIndex,omitempty"`
+
+	// Specification of the route exposing Kibana on OpenShift
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kibana Route"
+	Route *KibanaRouteSpec `json:"route,omitempty"`
+
+	// Kind of cluster Kibana runs on. Detected from the availability of the OpenShift
+	// route API when not set. On Kubernetes, Kibana is exposed through an Ingress and
+	// users authenticate with basic auth against the proxy.
+	//
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Platform"
+	Platform KibanaPlatform `json:"platform,omitempty"`
+
+	// Specification of the Ingress exposing Kibana on Kubernetes
+	//
+	// +optional
+	Ingress *KibanaIngressSpec `json:"ingress,omitempty"`
+}
+
+// KibanaAutoscalingSpec defines the bounds and the resource utilization targets of the
+// horizontal pod autoscaler of Kibana. Utilization is relative to the resource requests
+// of the pods, CPU utilization targets 80% when no target is set.
+type KibanaAutoscalingSpec struct {
+	// The minimum number of Kibana pods, defaults to 1
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// The maximum number of Kibana pods
+	//
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// The target average CPU utilization of the pods in percent of their requests
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// The target average memory utilization of the pods in percent of their requests
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+// KibanaPlatform is the kind of cluster Kibana runs on
+//
+// +kubebuilder:validation:Enum=OpenShift;Kubernetes
+type KibanaPlatform string
+
+const (
+	KibanaPlatformOpenShift  KibanaPlatform = "OpenShift"
+	KibanaPlatformKubernetes KibanaPlatform = "Kubernetes"
+)
+
+// KibanaIngressSpec defines the Ingress exposing Kibana on Kubernetes. The Ingress
+// controller needs to reach the proxy over HTTPS, e.g. with a backend protocol annotation.
+type KibanaIngressSpec struct {
+	// Host name of Kibana. The Ingress matches all hosts when not set.
+	//
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Name of the IngressClass of the Ingress
+	//
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// Name of the secret with the certificate the Ingress controller presents for the host
+	//
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Annotations of the Ingress, e.g. nginx.ingress.kubernetes.io/backend-protocol: HTTPS
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// KibanaRouteTermination is the TLS termination of the Kibana route
+//
+// +kubebuilder:validation:Enum=passthrough;reencrypt
+type KibanaRouteTermination string
+
+const (
+	KibanaRouteTerminationPassthrough KibanaRouteTermination = "passthrough"
+	KibanaRouteTerminationReencrypt   KibanaRouteTermination = "reencrypt"
+)
+
+// KibanaRouteSpec defines the host, the TLS configuration and the annotations of the Kibana route
+type KibanaRouteSpec struct {
+	// Host name of the route, generated by the router when not set.
+	// Passthrough routes present the certificate of the proxy, which includes the host name.
+	//
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// TLS termination of the route. The proxy only serves TLS, so the router either
+	// reencrypts the traffic or passes it through to the proxy.
+	//
+	// +kubebuilder:default:=reencrypt
+	// +optional
+	Termination KibanaRouteTermination `json:"termination,omitempty"`
+
+	// Name of the secret with the certificate presented by the router for reencrypt routes.
+	// The secret holds the keys tls.crt, tls.key and optionally ca.crt. The default
+	// certificate of the router is presented when not set. Not allowed with passthrough termination.
+	//
+	// +optional
+	CertificateSecretName string `json:"certificateSecretName,omitempty"`
+
+	// Annotations of the route, e.g. haproxy.router.openshift.io/timeout
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// KibanaIndexPattern defines an index pattern provisioned in Kibana
+type KibanaIndexPattern struct {
+	// Title of the index pattern, e.g. app-*. It is also used as ID of the saved object.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Title string `json:"title"`
+
+	// Name of the time field of the indices, defaults to @timestamp
+	//
+	// +optional
+	TimeFieldName string `json:"timeFieldName,omitempty"`
 }
 
 type ProxySpec struct {
@@ -43,31 +219,201 @@ type ProxySpec struct {
 	// +nullable
 	// +optional
 	Resources *corev1.ResourceRequirements `json:"resources"`
+
+	// Configuration of the OAuth proxy authenticating the users of Kibana
+	//
+	// +optional
+	OAuth *KibanaOAuthSpec `json:"oauth,omitempty"`
+
+	// Basic auth of the users of Kibana on Kubernetes, where the OpenShift OAuth server is
+	// not available. The image of the proxy needs to be set in the oauth section.
+	//
+	// +optional
+	BasicAuth *KibanaBasicAuthSpec `json:"basicAuth,omitempty"`
+}
+
+// KibanaBasicAuthSpec defines the users allowed to log in with basic auth
+type KibanaBasicAuthSpec struct {
+	// Name of the secret with the htpasswd file of the users under the key auth
+	//
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+}
+
+// KibanaOAuthSpec defines the sessions, the access restrictions and the image of the OAuth proxy.
+// Users need to satisfy all the restrictions to log in.
+type KibanaOAuthSpec struct {
+	// Expiry of the session cookie, e.g. 8h. Defaults to the access token inactivity
+	// timeout of the cluster OAuth config, or 24h when the cluster has none.
+	//
+	// +optional
+	SessionTimeout *metav1.Duration `json:"sessionTimeout,omitempty"`
+
+	// Groups allowed to log in, users need to be member of one of them
+	//
+	// +optional
+	AllowedGroups []string `json:"allowedGroups,omitempty"`
+
+	// Access the users logging in need to be granted, e.g. get on pods in a namespace
+	//
+	// +optional
+	SubjectAccessReview *authorizationv1.ResourceAttributes `json:"subjectAccessReview,omitempty"`
+
+	// Scopes requested in addition to user:info, user:check-access and user:list-projects
+	//
+	// +optional
+	AdditionalScopes []string `json:"additionalScopes,omitempty"`
+
+	// Image of the OAuth proxy. Defaults to the oauth-proxy image stream of the openshift
+	// namespace, it is required on Kubernetes.
+	//
+	// +optional
+	Image string `json:"image,omitempty"`
 }
 
 // KibanaStatus defines the observed state of Kibana
 // +k8s:openapi-gen=true
 type KibanaStatus struct {
+	// The generation of the spec observed by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The number of ready Kibana pods
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Ready Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// The URL of the Kibana route
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Kibana URL",xDescriptors="urn:alm:descriptor:org.w3:link"
+	URL string `json:"url,omitempty"`
+	// The current scale of Kibana reported by the horizontal pod autoscaler
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Autoscaling"
+	Autoscaling *KibanaAutoscalingStatus `json:"autoscaling,omitempty"`
+	// The import of the saved objects of each ConfigMap labelled with logging.openshift.io/kibana-saved-objects=true
+	// +optional
+	// +listType=map
+	// +listMapKey=configMap
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Saved Objects"
+	SavedObjects []KibanaSavedObjectsStatus `json:"savedObjects,omitempty"`
+	// The Available, Progressing, Degraded and Healthy conditions of the Kibana instance
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Deprecated: use readyReplicas, kept for consumers of the former status
 	// +optional
 	Replicas int32 `json:"replicas"`
+	// Deprecated: kept for consumers of the former status
 	// +optional
 	Deployment string `json:"deployment"`
+	// Deprecated: kept for consumers of the former status
 	// +optional
 	ReplicaSets []string `json:"replicaSets,omitempty"`
 	// The status for each of the Kibana pods for the Visualization component
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Kibana Status",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses"
 	Pods PodStateMap `json:"pods,omitempty"`
+	// Deprecated: use conditions, kept for consumers of the former status
+	// +optional
+	PodConditions map[string]ClusterConditions `json:"clusterCondition,omitempty"`
+}
+
+// KibanaAutoscalingStatus defines the scale of Kibana observed by the horizontal pod autoscaler
+type KibanaAutoscalingStatus struct {
+	// The number of Kibana pods last seen by the autoscaler
+	// +optional
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+	// The number of Kibana pods last calculated by the autoscaler
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+	// The last time the autoscaler scaled Kibana
 	// +optional
-	Conditions map[string]ClusterConditions `json:"clusterCondition,omitempty"`
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
 }
 
+// KibanaSavedObjectsStatus defines the import of the saved objects of a ConfigMap into Kibana
+type KibanaSavedObjectsStatus struct {
+	// The name of the ConfigMap holding the saved objects
+	ConfigMap string `json:"configMap"`
+	// The hash of the data of the ConfigMap last imported
+	// +optional
+	Hash string `json:"hash,omitempty"`
+	// The hash of the UIDs and restarts of the Kibana pods the saved objects were last imported into
+	// +optional
+	PodsHash string `json:"podsHash,omitempty"`
+	// Whether the last import of the saved objects succeeded
+	Imported bool `json:"imported"`
+	// The last time the saved objects were imported successfully
+	// +optional
+	LastImportTime *metav1.Time `json:"lastImportTime,omitempty"`
+	// The last time the import of the saved objects was attempted
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+	// The number of imports failed in a row for the same data and pods, delaying the next attempt
+	// +optional
+	FailedAttempts int32 `json:"failedAttempts,omitempty"`
+	// The error of the last import
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// legacyKibanaStatus is the former status of Kibana, a list holding a single entry
+type legacyKibanaStatus struct {
+	Replicas    int32                        `json:"replicas"`
+	Deployment  string                       `json:"deployment"`
+	ReplicaSets []string                     `json:"replicaSets,omitempty"`
+	Pods        PodStateMap                  `json:"pods,omitempty"`
+	Conditions  map[string]ClusterConditions `json:"clusterCondition,omitempty"`
+}
+
+// UnmarshalJSON decodes the status and converts the former list of statuses, still stored
+// for Kibana instances the operator has not reconciled since the upgrade. The converted status
+// lacks the observed generation, so the next status update of the operator stores the object.
+func (s *KibanaStatus) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var legacy []legacyKibanaStatus
+		if err := json.Unmarshal(trimmed, &legacy); err != nil {
+			return err
+		}
+
+		*s = KibanaStatus{}
+		if len(legacy) > 0 {
+			s.Replicas = legacy[0].Replicas
+			s.Deployment = legacy[0].Deployment
+			s.ReplicaSets = legacy[0].ReplicaSets
+			s.Pods = legacy[0].Pods
+			s.PodConditions = legacy[0].Conditions
+		}
+		return nil
+	}
+
+	type status KibanaStatus
+	return json.Unmarshal(data, (*status)(s))
+}
+
+const (
+	// KibanaAvailable is true when at least one Kibana pod is ready and the status API of Kibana
+	// does not report a red state
+	KibanaAvailable = "Available"
+	// KibanaProgressing is true while a rollout of the Kibana pods is in progress
+	KibanaProgressing = "Progressing"
+	// KibanaDegraded is true when the operator cannot reconcile the spec, the pods fail or the
+	// status API of Kibana reports a red state
+	KibanaDegraded = "Degraded"
+	// KibanaHealthy is true when the status API of every running Kibana pod reports a green state
+	KibanaHealthy = "Healthy"
+)
+
 // +kubebuilder:object:root=true
 // +k8s:openapi-gen=true
 // +kubebuilder:resource:path=kibanas,categories=logging,scope=Namespaced
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Management State",JSONPath=".spec.managementState",type=string
 // +kubebuilder:printcolumn:name="Replicas",JSONPath=".spec.replicas",type=integer
+// +kubebuilder:printcolumn:name="Ready",JSONPath=".status.readyReplicas",type=integer
+// +kubebuilder:printcolumn:name="Available",JSONPath=".status.conditions[?(@.type==\"Available\")].status",type=string
 // Kibana instance
 // +operator-sdk:csv:customresourcedefinitions:displayName="Kibana",resources={{Deployment,v1},{ConsoleExternalLogLink,v1},{ConsoleLink,v1},{ConfigMap,v1},{Role,v1},{RoleBinding,v1},{Route,v1},{Service,v1},{ServiceAccount,v1}}
 type Kibana struct {
@@ -76,7 +422,7 @@ type Kibana struct {
 
 	Spec KibanaSpec `json:"spec,omitempty"`
 
-	Status []KibanaStatus `json:"status,omitempty"`
+	Status KibanaStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -3,15 +3,18 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/ViaQ/logerr/v2/kverrors"
 	"github.com/go-logr/logr"
 	configv1 "github.com/openshift/api/config/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -19,6 +22,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -84,7 +88,11 @@ func (r *KibanaReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 	// keep track of the fact that we processed this kibana for future events and for mapping
 	registerKibanaNamespacedName(r.Log, request)
 
-	es, err := elasticsearch.GetElasticsearchCR(r.Client, request.Namespace)
+	if err := kibana.MigrateLegacyStatus(r.Client, kibanaInstance); err != nil {
+		return reconcileResult, err
+	}
+
+	es, err := r.getElasticsearch(kibanaInstance)
 	if err != nil {
 		r.Log.Info("skipping kibana reconciliation", "namespace", request.Namespace, "error", err)
 		return reconcileResult, nil
@@ -115,6 +123,66 @@ func (r *KibanaReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 	return reconcileResult, nil
 }
 
+// getElasticsearch returns the Elasticsearch CR of the spec reference or the one of the
+// namespace without reference. Unresolved references are reported on the Kibana status.
+func (r *KibanaReconciler) getElasticsearch(kibanaInstance *loggingv1.Kibana) (*loggingv1.Elasticsearch, error) {
+	ref := kibanaInstance.Spec.ElasticsearchRef
+	if ref == nil {
+		es, err := elasticsearch.GetElasticsearchCR(r.Client, kibanaInstance.Namespace)
+		if err != nil && !errors.IsNotFound(kverrors.Root(err)) {
+			message := fmt.Sprintf("unable to select the elasticsearch instance of the namespace: %s", err)
+			if statusErr := kibana.UpdateElasticsearchRefStatus(r.Client, kibanaInstance, message); statusErr != nil {
+				r.Log.Error(statusErr, "failed to update the elasticsearch reference status", "kibana", kibanaInstance.Name)
+			}
+		}
+		return es, err
+	}
+
+	es, err := elasticsearch.GetReferencedElasticsearchCR(r.Client, ref, kibanaInstance.Namespace)
+	if err != nil {
+		message := fmt.Sprintf("unable to resolve the elasticsearch reference %q: %s", ref.Name, err)
+		if statusErr := kibana.UpdateElasticsearchRefStatus(r.Client, kibanaInstance, message); statusErr != nil {
+			r.Log.Error(statusErr, "failed to update the elasticsearch reference status", "kibana", kibanaInstance.Name)
+		}
+		return nil, err
+	}
+
+	return es, nil
+}
+
+// getReferencingKibanaEvents returns requests for the Kibana CRs connecting to the
+// Elasticsearch CR, either by reference or as the cluster of their namespace
+func (r *KibanaReconciler) getReferencingKibanaEvents(a client.Object) []reconcile.Request {
+	kibanas := &loggingv1.KibanaList{}
+	if err := r.List(context.TODO(), kibanas); err != nil {
+		r.Log.Error(err, "failed to list kibana instances", "elasticsearch", a.GetName())
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, k := range kibanas.Items {
+		ref := k.Spec.ElasticsearchRef
+		if ref == nil {
+			if k.Namespace != a.GetNamespace() {
+				continue
+			}
+		} else {
+			namespace := ref.Namespace
+			if namespace == "" {
+				namespace = k.Namespace
+			}
+			if ref.Name != a.GetName() || namespace != a.GetNamespace() {
+				continue
+			}
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: k.Name, Namespace: k.Namespace},
+		})
+	}
+
+	return requests
+}
+
 // handleSecret returns true if metaname is such that (cr_name) matches or that (cr_name + "-proxy") matches
 func handleSecret(meta metav1.Object) bool {
 	// iterate over registeredKibanas that match the namespace
@@ -154,6 +222,26 @@ func handleConfigMap(meta metav1.Object) bool {
 	return false
 }
 
+// handleSavedObjectsConfigMap returns true if the configmap holds saved objects to import into a registered kibana
+func handleSavedObjectsConfigMap(meta metav1.Object) bool {
+	if meta.GetLabels()[constants.KibanaSavedObjectsLabel] != "true" {
+		return false
+	}
+
+	namespace := meta.GetNamespace()
+
+	registeredKibanas.mux.Lock()
+	defer registeredKibanas.mux.Unlock()
+
+	for _, kibana := range registeredKibanas.registered {
+		if kibana.Namespace == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
 // handlePod returns true if metaname contains a registered kibana name as substring
 func handlePod(meta metav1.Object) bool {
 	// iterate over registeredKibanas that match the namespace
@@ -263,11 +351,15 @@ func (r *KibanaReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		GenericFunc: func(e event.GenericEvent) bool { return false },
 	}
 
-	// Watch for changes to the additional trust bundle configmap
+	// Watch for changes to the additional trust bundle configmap and the saved objects configmaps
 	trustedBundlePred := predicate.Funcs{
-		UpdateFunc:  func(e event.UpdateEvent) bool { return handleConfigMap(e.ObjectNew) },
-		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
-		CreateFunc:  func(e event.CreateEvent) bool { return handleConfigMap(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return handleConfigMap(e.ObjectNew) || handleSavedObjectsConfigMap(e.ObjectNew) || handleSavedObjectsConfigMap(e.ObjectOld)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool { return handleSavedObjectsConfigMap(e.Object) },
+		CreateFunc: func(e event.CreateEvent) bool {
+			return handleConfigMap(e.Object) || handleSavedObjectsConfigMap(e.Object)
+		},
 		GenericFunc: func(e event.GenericEvent) bool { return false },
 	}
 
@@ -279,7 +371,7 @@ func (r *KibanaReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		GenericFunc: func(e event.GenericEvent) bool { return false },
 	}
 
-	// Watch for updates to the route
+	// Watch for updates to the route and the ingress
 	routePred := predicate.Funcs{
 		UpdateFunc:  func(e event.UpdateEvent) bool { return true },
 		DeleteFunc:  func(e event.DeleteEvent) bool { return true },
@@ -303,19 +395,62 @@ func (r *KibanaReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		GenericFunc: func(e event.GenericEvent) bool { return false },
 	}
 
+	// Watch for the creation and removal of the referenced elasticsearch, its cert management annotation
+	// and the namespaces of the kibana instances it allows
+	esPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld.GetAnnotations()[constants.EOCertManagementLabel] != e.ObjectNew.GetAnnotations()[constants.EOCertManagementLabel] {
+				return true
+			}
+			oldES, okOld := e.ObjectOld.(*loggingv1.Elasticsearch)
+			newES, okNew := e.ObjectNew.(*loggingv1.Elasticsearch)
+			return okOld && okNew && !reflect.DeepEqual(oldES.Spec.AllowedKibanaNamespaces, newES.Spec.AllowedKibanaNamespaces)
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return true },
+		CreateFunc:  func(e event.CreateEvent) bool { return true },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+
 	// TODO: replace the watches with For and Own
-	return ctrl.NewControllerManagedBy(mgr).
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		Named("kibana-controller").
 		For(&loggingv1.Kibana{}).
 		Watches(&source.Kind{Type: &corev1.Secret{}}, namespacedMapHandler, builder.WithPredicates(secretPred)).
-		Watches(&source.Kind{Type: &configv1.Proxy{}}, globalMapHandler, builder.WithPredicates(proxyPred)).
 		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, namespacedMapHandler, builder.WithPredicates(trustedBundlePred)).
 		Watches(&source.Kind{Type: &corev1.Pod{}}, namespacedMapHandler, builder.WithPredicates(podPred)).
-		Watches(&source.Kind{Type: &routev1.Route{}}, &handler.EnqueueRequestForOwner{
+		Watches(&source.Kind{Type: &networkingv1.Ingress{}}, &handler.EnqueueRequestForOwner{
 			OwnerType:    &loggingv1.Kibana{},
 			IsController: true,
 		}, builder.WithPredicates(routePred)).
-		Watches(&source.Kind{Type: &imagev1.ImageStream{}}, globalMapHandler, builder.WithPredicates(isPred)).
-		Watches(&source.Kind{Type: &configv1.OAuth{}}, globalMapHandler, builder.WithPredicates(oauthPred)).
-		Complete(r)
+		Watches(&source.Kind{Type: &loggingv1.Elasticsearch{}}, handler.EnqueueRequestsFromMapFunc(r.getReferencingKibanaEvents), builder.WithPredicates(esPred))
+
+	// the openshift APIs are not available when Kibana runs on Kubernetes
+	if isAPIAvailable(mgr, &configv1.Proxy{}) {
+		bldr = bldr.Watches(&source.Kind{Type: &configv1.Proxy{}}, globalMapHandler, builder.WithPredicates(proxyPred))
+	}
+	if isAPIAvailable(mgr, &routev1.Route{}) {
+		bldr = bldr.Watches(&source.Kind{Type: &routev1.Route{}}, &handler.EnqueueRequestForOwner{
+			OwnerType:    &loggingv1.Kibana{},
+			IsController: true,
+		}, builder.WithPredicates(routePred))
+	}
+	if isAPIAvailable(mgr, &imagev1.ImageStream{}) {
+		bldr = bldr.Watches(&source.Kind{Type: &imagev1.ImageStream{}}, globalMapHandler, builder.WithPredicates(isPred))
+	}
+	if isAPIAvailable(mgr, &configv1.OAuth{}) {
+		bldr = bldr.Watches(&source.Kind{Type: &configv1.OAuth{}}, globalMapHandler, builder.WithPredicates(oauthPred))
+	}
+
+	return bldr.Complete(r)
+}
+
+// isAPIAvailable returns true if the API of the object is served by the cluster
+func isAPIAvailable(mgr ctrl.Manager, obj client.Object) bool {
+	gvk, err := apiutil.GVKForObject(obj, mgr.GetScheme())
+	if err != nil {
+		return false
+	}
+
+	_, err = mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	return err == nil
 }
@@ -28,6 +28,9 @@ const (
 	ConsoleDashboardLabel          = "console.openshift.io/dashboard"
 	LoggingHashLabel               = "logging.openshift.io/hash"
 	ElasticsearchDashboardFileName = "openshift-elasticsearch.json"
+
+	// KibanaSavedObjectsLabel marks the configmaps holding saved objects to import into Kibana
+	KibanaSavedObjectsLabel = "logging.openshift.io/kibana-saved-objects"
 )
 
 var (
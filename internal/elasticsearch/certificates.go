@@ -168,6 +168,20 @@ func NewCertificateRequest(log logr.Logger, clusterName, namespace string, owner
 	}
 }
 
+// AddDNSNames adds names to the SANs of the certificate of the component
+func (cr *CertificateRequest) AddDNSNames(componentName string, names ...string) {
+	ext, ok := cr.Extensions[componentName]
+	if !ok {
+		return
+	}
+	for _, name := range names {
+		if name != "" && !slices.Contains(ext.dns, name) {
+			ext.dns = append(ext.dns, name)
+		}
+	}
+	cr.Extensions[componentName] = ext
+}
+
 func (cr *CertificateRequest) GenerateComponentCerts(secretName, cn string) {
 	certMutex.Lock()
 	defer certMutex.Unlock()
@@ -207,10 +221,21 @@ func (cr *CertificateRequest) GenerateComponentCerts(secretName, cn string) {
 	}
 }
 
-func (cr *CertificateRequest) GenerateKibanaCerts(componentName string) {
+// GenerateKibanaCerts ensures the secrets of the Kibana instance in namespace, signed by the
+// CA of the cluster so that Kibana may connect to it from another namespace than the cluster's.
+// The hosts are added to the certificate of the proxy.
+func (cr *CertificateRequest) GenerateKibanaCerts(namespace string, ownerRef metav1.OwnerReference, hosts ...string) {
 	certMutex.Lock()
 	defer certMutex.Unlock()
-	key := client.ObjectKey{Name: kibanaSecretName, Namespace: cr.Namespace}
+
+	if namespace != cr.Namespace {
+		ext := cr.Extensions[kibanaInternalComponentName]
+		ext.dns = []string{`kibana`, `kibana.` + namespace + `.svc`}
+		cr.Extensions[kibanaInternalComponentName] = ext
+	}
+	cr.AddDNSNames(kibanaInternalComponentName, hosts...)
+
+	key := client.ObjectKey{Name: kibanaSecretName, Namespace: namespace}
 	s, err := secret.Get(context.TODO(), cr.K8sClient, key)
 	if err != nil && !apierrors.IsNotFound(kverrors.Root(err)) {
 		cr.Log.Error(err, "unable to get secret")
@@ -240,12 +265,12 @@ func (cr *CertificateRequest) GenerateKibanaCerts(componentName string) {
 		kibanaComponentCAName:   ca.cert,
 	}
 
-	if err = CreateOrUpdateSecretWithOwnerRef(kibanaSecretName, cr.Namespace, kibanaSecretData, cr.K8sClient, cr.OwnerRef); err != nil {
+	if err = CreateOrUpdateSecretWithOwnerRef(kibanaSecretName, namespace, kibanaSecretData, cr.K8sClient, ownerRef); err != nil {
 		cr.Log.Error(err, "Unable to create secret for kibana component")
 		return
 	}
 
-	key = client.ObjectKey{Name: getKibanaProxySecretName(kibanaSecretName), Namespace: cr.Namespace}
+	key = client.ObjectKey{Name: getKibanaProxySecretName(kibanaSecretName), Namespace: namespace}
 	s, err = secret.Get(context.TODO(), cr.K8sClient, key)
 	if err != nil && !apierrors.IsNotFound(kverrors.Root(err)) {
 		cr.Log.Error(err, "unable to get secret")
@@ -279,7 +304,7 @@ func (cr *CertificateRequest) GenerateKibanaCerts(componentName string) {
 		kibanaInternalKeyName:           kibanaProxyCert.key,
 	}
 
-	if err = CreateOrUpdateSecretWithOwnerRef(getKibanaProxySecretName(kibanaSecretName), cr.Namespace, secretData, cr.K8sClient, cr.OwnerRef); err != nil {
+	if err = CreateOrUpdateSecretWithOwnerRef(getKibanaProxySecretName(kibanaSecretName), namespace, secretData, cr.K8sClient, ownerRef); err != nil {
 		cr.Log.Error(err, "Unable to create secret for kibana-proxy")
 		return
 	}
@@ -357,8 +382,9 @@ func (cr *CertificateRequest) EnsureCert(componentName string, cert *certificate
 		isSignedCorrectly = cert.x509Cert.CheckSignatureFrom(ca.x509Cert) == nil
 	}
 
-	// validate that the cert isn't expired and is signed correctly
-	if !isValidCert(cert.x509Cert, cert.privKey, componentName, true) || !isSignedCorrectly {
+	// validate that the cert isn't expired, is signed correctly and covers all names of the component
+	if !isValidCert(cert.x509Cert, cert.privKey, componentName, true) || !isSignedCorrectly ||
+		!hasDNSNames(cert.x509Cert, cr.Extensions[componentName].dns) {
 		err := cr.generateCert(componentName, cert, ca)
 		if err != nil {
 			return err
@@ -642,6 +668,18 @@ func isValidCert(x509Cert *x509.Certificate, rsaPrivKey *rsa.PrivateKey, commonN
 	return true
 }
 
+func hasDNSNames(x509Cert *x509.Certificate, names []string) bool {
+	if x509Cert == nil {
+		return false
+	}
+	for _, name := range names {
+		if !slices.Contains(x509Cert.DNSNames, name) {
+			return false
+		}
+	}
+	return true
+}
+
 func certWillExpireSoon(cert *x509.Certificate) bool {
 	certExpiration := cert.NotAfter
 	return time.Now().After(certExpiration.Add(time.Hour * -1))
@@ -13,7 +13,9 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/retry"
 
 	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
@@ -28,6 +30,10 @@ var (
 
 var aliasNeededMap map[string]bool
 
+// emergencyRetentionMap tracks the clusters which exceeded the flood stage watermark
+// and are not yet back below the high watermark
+var emergencyRetentionMap map[string]bool
+
 func FlushNodes(clusterName, namespace string) {
 	nodes[nodeMapKey(clusterName, namespace)] = []NodeTypeInterface{}
 }
@@ -85,24 +91,24 @@ func (er *ElasticsearchRequest) CreateOrUpdateElasticsearchCluster() error {
 		_ = er.UpdateClusterStatus()
 	}
 
-	// if there is a node currently being upgraded, work on that first
-	inProgressNode := er.getNodeUpgradeInProgress()
+	// if there are nodes currently being upgraded, work on those first
+	inProgressNodes := er.getNodesUpgradeInProgress()
 	scheduledNodes := er.getScheduledUpgradeNodes()
 
-	// Check if we have a node that was in the progress -- if so, continue updating it
-	if inProgressNode != nil {
-		// Check to see if the inProgressNode was being updated or restarted
-		if _, ok := containsNodeTypeInterface(inProgressNode, scheduledNodes); ok {
-			if err := er.PerformNodeUpdate(inProgressNode); err != nil {
-				er.ll.Error(err, "unable to update node")
+	// Check if we have nodes that were in progress -- if so, continue updating them
+	if len(inProgressNodes) > 0 {
+		// Check to see if the inProgressNodes were being updated or restarted
+		if _, ok := containsNodeTypeInterface(inProgressNodes[0], scheduledNodes); ok {
+			if err := er.PerformNodesUpdate(inProgressNodes); err != nil {
+				er.ll.Error(err, "unable to update nodes", "nodes", nodeNames(inProgressNodes))
 				return er.UpdateClusterStatus()
 			}
 
-			// update scheduled nodes since we were able to complete upgrade for inProgressNode
+			// update scheduled nodes since we were able to complete upgrade for inProgressNodes
 			scheduledNodes = er.getScheduledUpgradeNodes()
 		} else {
-			if err := er.PerformNodeRestart(inProgressNode); err != nil {
-				er.ll.Error(err, "unable to restart node", "node", inProgressNode.name())
+			if err := er.PerformNodesRestart(inProgressNodes); err != nil {
+				er.ll.Error(err, "unable to restart nodes", "nodes", nodeNames(inProgressNodes))
 				return er.UpdateClusterStatus()
 			}
 		}
@@ -130,7 +136,8 @@ func (er *ElasticsearchRequest) CreateOrUpdateElasticsearchCluster() error {
 		minVersionArray, _ := comparators.Version(expectedMinVersion).ToArray()
 
 		// if it is < what we expect (6.0) then do full cluster update:
-		if comparators.CompareVersionArrays(versionArray, minVersionArray) > 0 {
+		// OpenSearch versions start over at 1.0 and always support rolling updates
+		if comparators.CompareVersionArrays(versionArray, minVersionArray) > 0 && !isOpenSearchImage(getESImage()) {
 			// perform a full cluster update
 			if err := er.PerformFullClusterUpdate(scheduledNodes); err != nil {
 				er.ll.Error(err, "failed to perform full cluster update")
@@ -158,6 +165,11 @@ func (er *ElasticsearchRequest) CreateOrUpdateElasticsearchCluster() error {
 				return err
 			}
 
+			// proxy image changes are pushed out without restarting Elasticsearch
+			if err := node.progressProxyChanges(); err != nil {
+				er.ll.Error(err, "unable to progress proxy changes for node", "node", node.name())
+			}
+
 			addNodeState(node, nodeStatus)
 
 			if nodeStatus.UpgradeStatus.ScheduledForCertRedeploy == v1.ConditionTrue ||
@@ -173,15 +185,30 @@ func (er *ElasticsearchRequest) CreateOrUpdateElasticsearchCluster() error {
 		// ensure that MinMasters is (n / 2 + 1)
 		er.updateMinMasters()
 
+		// remove voting config exclusions of master nodes which left the cluster
+		er.clearVotingConfigExclusions()
+
 		// update our template primary shard counts in case they changed
 		er.updatePrimaryShards()
 
+		// update the slow log thresholds of templates and indices in case they changed
+		er.updateSlowLog()
+
 		// ensure we always have shard allocation to All if we aren't doing an update...
 		er.tryEnsureAllShardAllocation()
 
 		// we only want to update our replicas if we aren't in the middle up an update
 		er.updateReplicas()
 
+		// apply the persistent cluster settings from the spec and revert any drift
+		er.updateClusterSettings()
+
+		// configure the remote clusters from the spec and report their connectivity
+		er.updateRemoteClusters()
+
+		// apply the logger levels from the spec and revert expired debug logging
+		er.updateLoggers()
+
 		// add alias to old indices if they exist and don't have one
 		// this should be removed after one release...
 		if er.ClusterReady() {
@@ -205,6 +232,26 @@ func (er *ElasticsearchRequest) CreateOrUpdateElasticsearchCluster() error {
 	return er.UpdateClusterStatus()
 }
 
+// getNodesUpgradeInProgress returns all nodes under upgrade, as a rolling
+// update or restart may progress several data nodes together
+func (er *ElasticsearchRequest) getNodesUpgradeInProgress() []NodeTypeInterface {
+	cluster := er.cluster
+	inProgressNodes := []NodeTypeInterface{}
+
+	for _, node := range cluster.Status.Nodes {
+		if node.UpgradeStatus.UnderUpgrade == v1.ConditionTrue {
+			for _, nodeTypeInterface := range nodes[nodeMapKey(cluster.Name, cluster.Namespace)] {
+				if node.DeploymentName == nodeTypeInterface.name() ||
+					node.StatefulSetName == nodeTypeInterface.name() {
+					inProgressNodes = append(inProgressNodes, nodeTypeInterface)
+				}
+			}
+		}
+	}
+
+	return inProgressNodes
+}
+
 func (er *ElasticsearchRequest) getNodeUpgradeInProgress() NodeTypeInterface {
 	cluster := er.cluster
 
@@ -342,6 +389,15 @@ func (er *ElasticsearchRequest) populateNodes() error {
 				}
 			}
 
+			// without Zen discovery master nodes need to leave the voting configuration first
+			if isMasterNodeType(node) && !usesZenDiscovery(getESImage()) {
+				if err := er.esClient.AddVotingConfigExclusions(getElasticsearchNodeNames(node)); err != nil {
+					er.ll.Error(err, "unable to exclude master node from voting configuration", "node", node.name())
+					currentNodes = append(currentNodes, node)
+					continue
+				}
+			}
+
 			if err := node.delete(); err != nil {
 				er.ll.Error(err, "unable to delete node")
 			}
@@ -411,6 +467,16 @@ func addNodeState(node NodeTypeInterface, nodeStatus *api.ElasticsearchNodeStatu
 
 func (er *ElasticsearchRequest) checkWatermarkAndUnblockIndices() {
 	er.refreshDiskWatermarkThresholds()
+	if emergencyRetentionMap == nil {
+		emergencyRetentionMap = make(map[string]bool)
+	}
+	key := nodeMapKey(er.cluster.Name, er.cluster.Namespace)
+	if !er.isDiskUtilizationBelowFloodWatermark() {
+		emergencyRetentionMap[key] = true
+	}
+	if emergencyRetentionMap[key] {
+		er.applyEmergencyRetention()
+	}
 	if er.isDiskUtilizationBelowFloodWatermark() {
 		indices, err := er.esClient.GetAllIndices("")
 		if err != nil {
@@ -429,7 +495,105 @@ func (er *ElasticsearchRequest) checkWatermarkAndUnblockIndices() {
 	}
 }
 
+// applyEmergencyRetention deletes the oldest non-write index of the low-priority aliases,
+// in the order the aliases are listed, until all nodes are below the high watermark. At most
+// one index is deleted per reconciliation because the node disk stats lag behind the deletion.
+func (er *ElasticsearchRequest) applyEmergencyRetention() {
+	retention := er.cluster.Spec.EmergencyRetention
+	if retention == nil || len(retention.Aliases) == 0 {
+		return
+	}
+	if er.isDiskUtilizationBelowHighWatermark() {
+		delete(emergencyRetentionMap, nodeMapKey(er.cluster.Name, er.cluster.Namespace))
+		return
+	}
+
+	for _, alias := range retention.Aliases {
+		candidates, err := er.emergencyRetentionCandidates(alias)
+		if err != nil {
+			er.ll.Error(err, "Unable to list indices for emergency retention", "alias", alias)
+			continue
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		index := candidates[0]
+		if err := er.esClient.DeleteIndex(index); err != nil {
+			er.ll.Error(err, "Unable to delete index for emergency retention", "alias", alias, "index", index)
+			return
+		}
+
+		er.ll.Info("Deleted index to free disk space above flood stage watermark", "alias", alias, "index", index)
+		metrics.IncrementEmergencyIndexDeletions(alias)
+		er.recordEmergencyIndexDeletionEvent(alias, index)
+		return
+	}
+}
+
+// emergencyRetentionCandidates returns the indices of the alias from the oldest to the newest,
+// skipping write indices and the security index
+func (er *ElasticsearchRequest) emergencyRetentionCandidates(alias string) ([]string, error) {
+	indices, err := er.esClient.ListIndicesByCreationDate(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	writeIndices, err := er.esClient.ListWriteIndices(alias)
+	if err != nil {
+		return nil, err
+	}
+	skip := sets.NewString(writeIndices...)
+	skip.Insert(constants.SecurityIndex)
+
+	candidates := []string{}
+	for _, index := range indices {
+		if !skip.Has(index) {
+			candidates = append(candidates, index)
+		}
+	}
+	return candidates, nil
+}
+
+func (er *ElasticsearchRequest) recordEmergencyIndexDeletionEvent(alias, index string) {
+	now := metav1.Now()
+	event := &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-", er.cluster.Name),
+			Namespace:    er.cluster.Namespace,
+		},
+		InvolvedObject: v1.ObjectReference{
+			APIVersion: api.GroupVersion.String(),
+			Kind:       "Elasticsearch",
+			Name:       er.cluster.Name,
+			Namespace:  er.cluster.Namespace,
+			UID:        er.cluster.UID,
+		},
+		Reason:         "EmergencyIndexDeletion",
+		Message:        fmt.Sprintf("Deleted index %q of alias %q because a node exceeded the flood stage disk watermark", index, alias),
+		Type:           v1.EventTypeWarning,
+		Count:          1,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Source: v1.EventSource{
+			Component: "elasticsearch-operator",
+		},
+	}
+
+	if err := er.client.Create(context.TODO(), event); err != nil {
+		er.ll.Error(err, "Unable to record event for emergency index deletion", "index", index)
+	}
+}
+
 func (er *ElasticsearchRequest) isDiskUtilizationBelowFloodWatermark() bool {
+	return !er.anyNodeExceedsWatermark(exceedsFloodWatermark)
+}
+
+func (er *ElasticsearchRequest) isDiskUtilizationBelowHighWatermark() bool {
+	return !er.anyNodeExceedsWatermark(exceedsHighWatermark)
+}
+
+func (er *ElasticsearchRequest) anyNodeExceedsWatermark(exceedsWatermark func(resource.Quantity, float64) bool) bool {
 	for _, nodeTypeInterface := range nodes[nodeMapKey(er.cluster.Name, er.cluster.Namespace)] {
 		usage, percent, err := er.esClient.GetNodeDiskUsage(nodeTypeInterface.name())
 		if err != nil {
@@ -443,9 +607,9 @@ func (er *ElasticsearchRequest) isDiskUtilizationBelowFloodWatermark() bool {
 			continue
 		}
 
-		if exceedsFloodWatermark(quantity, percent) {
-			return false
+		if exceedsWatermark(quantity, percent) {
+			return true
 		}
 	}
-	return true
+	return false
 }
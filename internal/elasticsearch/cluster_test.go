@@ -1,14 +1,23 @@
 package elasticsearch
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
 	"testing"
 
+	"github.com/ViaQ/logerr/v2/log"
+
 	elasticsearchv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
 	"github.com/openshift/elasticsearch-operator/internal/elasticsearch/esclient"
 	"github.com/openshift/elasticsearch-operator/test/helpers"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -56,6 +65,88 @@ func TestDiskUtilizationBelowFloodWatermark(t *testing.T) {
 	}
 }
 
+func TestApplyEmergencyRetention(t *testing.T) {
+	nodes = map[string][]NodeTypeInterface{}
+
+	highPct, floodPct := float64(85), float64(95)
+	oldHighPct, oldFloodPct := DiskWatermarkHighPct, DiskWatermarkFloodPct
+	oldHighAbs, oldFloodAbs := DiskWatermarkHighAbs, DiskWatermarkFloodAbs
+	DiskWatermarkHighPct, DiskWatermarkFloodPct = &highPct, &floodPct
+	DiskWatermarkHighAbs, DiskWatermarkFloodAbs = nil, nil
+	defer func() {
+		DiskWatermarkHighPct, DiskWatermarkFloodPct = oldHighPct, oldFloodPct
+		DiskWatermarkHighAbs, DiskWatermarkFloodAbs = oldHighAbs, oldFloodAbs
+	}()
+
+	const (
+		esCluster   = "elasticsearch"
+		esNamespace = "openshift-logging"
+		nodeStats   = `{"nodes": {"7EN-Wa_EQC6LoANvWcoyHQ": {"name": "elasticsearch-cdm-1-deadbeef", "fs": {"total": {"total_in_bytes": 100000000000, "available_in_bytes": %d}}}}}`
+	)
+
+	chatter := helpers.NewFakeElasticsearchChatter(map[string]helpers.FakeElasticsearchResponses{
+		"_cat/indices/audit?format=json&h=index&s=creation.date": {
+			{
+				StatusCode: 200,
+				Body:       `[{"index": "audit-000001"}, {"index": "audit-000002"}, {"index": "audit-000003"}]`,
+			},
+		},
+		"audit/_alias": {
+			{
+				StatusCode: 200,
+				Body:       `{"audit-000001": {"aliases": {"audit": {}}}, "audit-000002": {"aliases": {"audit": {}}}, "audit-000003": {"aliases": {"audit": {}, "audit-write": {"is_write_index": true}}}}`,
+			},
+		},
+		"_nodes/stats/fs": {
+			{StatusCode: 200, Body: fmt.Sprintf(nodeStats, 3000000000)},
+			{StatusCode: 200, Body: fmt.Sprintf(nodeStats, 3000000000)},
+		},
+		"audit-000001": {
+			{StatusCode: 200, Body: `{"acknowledged": true}`},
+		},
+	})
+
+	k8sClient := fake.NewFakeClient()
+	cluster := &elasticsearchv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      esCluster,
+			Namespace: esNamespace,
+		},
+		Spec: elasticsearchv1.ElasticsearchSpec{
+			EmergencyRetention: &elasticsearchv1.ElasticsearchEmergencyRetentionSpec{
+				Aliases: []string{"audit"},
+			},
+		},
+	}
+	er := ElasticsearchRequest{
+		cluster:  cluster,
+		client:   k8sClient,
+		esClient: helpers.NewFakeElasticsearchClient(esCluster, esNamespace, k8sClient, chatter),
+		ll:       log.NewLogger("emergency-retention-test"),
+	}
+	nodes[nodeMapKey(esCluster, esNamespace)] = populateSingleNode(esCluster)
+
+	er.applyEmergencyRetention()
+
+	req, found := chatter.GetRequest("audit-000001")
+	if !found || req.Method != http.MethodDelete {
+		t.Fatalf("expected the oldest index to be deleted, got %v", req)
+	}
+	for _, index := range []string{"audit-000002", "audit-000003"} {
+		if _, found := chatter.GetRequest(index); found {
+			t.Errorf("expected index %q to be kept until the next reconciliation", index)
+		}
+	}
+
+	events := &corev1.EventList{}
+	if err := k8sClient.List(context.TODO(), events); err != nil {
+		t.Fatalf("failed to list events: %s", err)
+	}
+	if len(events.Items) != 1 || events.Items[0].Reason != "EmergencyIndexDeletion" {
+		t.Errorf("expected one EmergencyIndexDeletion event, got %v", events.Items)
+	}
+}
+
 func populateSingleNode(clusterName string) []NodeTypeInterface {
 	nodes := []NodeTypeInterface{}
 	deployments := []runtime.Object{
@@ -76,3 +167,207 @@ func populateSingleNode(clusterName string) []NodeTypeInterface {
 	}
 	return nodes
 }
+
+func TestGetRollingBatches(t *testing.T) {
+	newDataNode := func(name, zone string) NodeTypeInterface {
+		node := &deploymentNode{}
+		node.self.Name = name
+		if zone != "" {
+			node.self.Spec.Template.Spec.NodeSelector = map[string]string{corev1.LabelTopologyZone: zone}
+		}
+		return node
+	}
+	masterNode := &statefulSetNode{}
+	masterNode.self.Name = "elasticsearch-m-deadbeef"
+
+	scheduled := []NodeTypeInterface{
+		newDataNode("elasticsearch-cd-deadbeef-1", "zone-a"),
+		newDataNode("elasticsearch-cd-deadbeef-2", "zone-b"),
+		masterNode,
+		newDataNode("elasticsearch-cd-deadbeef-3", "zone-a"),
+		newDataNode("elasticsearch-cd-deadbeef-4", "zone-b"),
+		newDataNode("elasticsearch-cd-deadbeef-5", "zone-a"),
+	}
+
+	tests := []struct {
+		desc           string
+		policy         elasticsearchv1.RedundancyPolicyType
+		maxUnavailable int32
+		want           [][]string
+	}{
+		{
+			desc:   "one node at a time by default",
+			policy: elasticsearchv1.FullRedundancy,
+			want: [][]string{
+				{"elasticsearch-cd-deadbeef-1"},
+				{"elasticsearch-cd-deadbeef-2"},
+				{"elasticsearch-m-deadbeef"},
+				{"elasticsearch-cd-deadbeef-3"},
+				{"elasticsearch-cd-deadbeef-4"},
+				{"elasticsearch-cd-deadbeef-5"},
+			},
+		},
+		{
+			desc:           "grouped by zone",
+			policy:         elasticsearchv1.FullRedundancy,
+			maxUnavailable: 3,
+			want: [][]string{
+				{"elasticsearch-m-deadbeef"},
+				{"elasticsearch-cd-deadbeef-1", "elasticsearch-cd-deadbeef-3", "elasticsearch-cd-deadbeef-5"},
+				{"elasticsearch-cd-deadbeef-2", "elasticsearch-cd-deadbeef-4"},
+			},
+		},
+		{
+			desc:           "capped by replica count",
+			policy:         elasticsearchv1.MultipleRedundancy,
+			maxUnavailable: 3,
+			want: [][]string{
+				{"elasticsearch-m-deadbeef"},
+				{"elasticsearch-cd-deadbeef-1", "elasticsearch-cd-deadbeef-3"},
+				{"elasticsearch-cd-deadbeef-5"},
+				{"elasticsearch-cd-deadbeef-2", "elasticsearch-cd-deadbeef-4"},
+			},
+		},
+		{
+			desc:           "zero redundancy restarts one node at a time",
+			policy:         elasticsearchv1.ZeroRedundancy,
+			maxUnavailable: 3,
+			want: [][]string{
+				{"elasticsearch-cd-deadbeef-1"},
+				{"elasticsearch-cd-deadbeef-2"},
+				{"elasticsearch-m-deadbeef"},
+				{"elasticsearch-cd-deadbeef-3"},
+				{"elasticsearch-cd-deadbeef-4"},
+				{"elasticsearch-cd-deadbeef-5"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			er := ElasticsearchRequest{
+				cluster: &elasticsearchv1.Elasticsearch{
+					Spec: elasticsearchv1.ElasticsearchSpec{
+						RedundancyPolicy: test.policy,
+						MaxUnavailable:   test.maxUnavailable,
+						Nodes: []elasticsearchv1.ElasticsearchNode{
+							{
+								Roles:     []elasticsearchv1.ElasticsearchNodeRole{"client", "data"},
+								NodeCount: 5,
+							},
+						},
+					},
+				},
+			}
+
+			got := [][]string{}
+			for _, batch := range er.getRollingBatches(scheduled) {
+				got = append(got, nodeNames(batch))
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got batches %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetInitialMasterNodes(t *testing.T) {
+	cdmUUID := "abc"
+	mUUID := "xyz"
+	dUUID := "def"
+	cluster := &elasticsearchv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Name: "elasticsearch"},
+		Spec: elasticsearchv1.ElasticsearchSpec{
+			Nodes: []elasticsearchv1.ElasticsearchNode{
+				{
+					Roles:     []elasticsearchv1.ElasticsearchNodeRole{elasticsearchv1.ElasticsearchRoleClient, elasticsearchv1.ElasticsearchRoleData, elasticsearchv1.ElasticsearchRoleMaster},
+					NodeCount: 2,
+					GenUUID:   &cdmUUID,
+				},
+				{
+					Roles:     []elasticsearchv1.ElasticsearchNodeRole{elasticsearchv1.ElasticsearchRoleMaster},
+					NodeCount: 1,
+					GenUUID:   &mUUID,
+				},
+				{
+					Roles:     []elasticsearchv1.ElasticsearchNodeRole{elasticsearchv1.ElasticsearchRoleData},
+					NodeCount: 1,
+					GenUUID:   &dUUID,
+				},
+			},
+		},
+	}
+
+	want := []string{"elasticsearch-cdm-abc-1", "elasticsearch-cdm-abc-2", "elasticsearch-m-xyz-0"}
+	if got := getInitialMasterNodes(cluster); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestStatefulSetScaleDownExcludesDepartingMasters(t *testing.T) {
+	t.Setenv("RELATED_IMAGE_ELASTICSEARCH", "quay.io/openshift-logging/elasticsearch7:7.16")
+
+	const exclusionsURI = "_cluster/voting_config_exclusions?node_names=elasticsearch-m-deadbeef-1,elasticsearch-m-deadbeef-2"
+
+	tests := []struct {
+		desc         string
+		responses    map[string]helpers.FakeElasticsearchResponses
+		wantReplicas int32
+	}{
+		{
+			desc: "scaled down once the departing masters are excluded",
+			responses: map[string]helpers.FakeElasticsearchResponses{
+				exclusionsURI: {{StatusCode: 200, Body: `{}`}},
+			},
+			wantReplicas: 1,
+		},
+		{
+			desc: "kept when the departing masters cannot be excluded",
+			responses: map[string]helpers.FakeElasticsearchResponses{
+				exclusionsURI: {{StatusCode: 500, Body: `{"error": "timed out"}`}},
+			},
+			wantReplicas: 3,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "elasticsearch-m-deadbeef",
+					Namespace: "openshift-logging",
+					Labels:    map[string]string{"es-node-master": "true"},
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: pointer.Int32(3),
+				},
+			}
+			k8sClient := fake.NewFakeClient(sts)
+			chatter := helpers.NewFakeElasticsearchChatter(test.responses)
+
+			node := &statefulSetNode{
+				self:     *sts.DeepCopy(),
+				replicas: 1,
+				client:   k8sClient,
+				esClient: helpers.NewFakeElasticsearchClient("elasticsearch", "openshift-logging", k8sClient, chatter),
+				l:        log.NewLogger("statefulset-scale-test"),
+			}
+			node.scale()
+
+			if _, found := chatter.GetRequest(exclusionsURI); !found {
+				t.Errorf("expected the departing masters to be excluded from the voting configuration")
+			}
+
+			current := &appsv1.StatefulSet{}
+			if err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(sts), current); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := *current.Spec.Replicas; got != test.wantReplicas {
+				t.Errorf("got %d replicas, want %d", got, test.wantReplicas)
+			}
+		})
+	}
+}
@@ -161,19 +161,23 @@ func (er *ElasticsearchRequest) PerformFullClusterRestart(nodes []NodeTypeInterf
 }
 
 func (er *ElasticsearchRequest) PerformNodeRestart(node NodeTypeInterface) error {
-	scheduledNode := []NodeTypeInterface{node}
+	return er.PerformNodesRestart([]NodeTypeInterface{node})
+}
 
+// PerformNodesRestart restarts the given nodes together. The upgrade status of the
+// first node drives the restart phases and is mirrored to the rest of the nodes.
+func (er *ElasticsearchRequest) PerformNodesRestart(scheduledNodes []NodeTypeInterface) error {
 	r := ClusterRestart{
 		log:              er.ll,
 		client:           er.esClient,
 		clusterName:      er.cluster.Name,
 		clusterNamespace: er.cluster.Namespace,
-		scheduledNodes:   scheduledNode,
+		scheduledNodes:   scheduledNodes,
 	}
 
 	restarter := Restarter{
 		log:              er.ll,
-		scheduledNodes:   scheduledNode,
+		scheduledNodes:   scheduledNodes,
 		clusterName:      er.cluster.Name,
 		clusterNamespace: er.cluster.Namespace,
 		precheck:         r.ensureClusterHealthValid,
@@ -184,31 +188,33 @@ func (er *ElasticsearchRequest) PerformNodeRestart(node NodeTypeInterface) error
 	}
 
 	updateStatus := func() {
-		if err := er.setNodeStatus(node, restarter.nodeStatus, &er.cluster.Status); err != nil {
-			er.ll.Error(err, "unable to update node status")
-		}
+		er.setNodesUpgradeStatus(scheduledNodes, restarter.nodeStatus)
 	}
 
 	restarter.setNodeConditions(updateStatus)
 
-	restarter.nodeStatus = er.getNodeState(node)
+	restarter.nodeStatus = er.getNodeState(scheduledNodes[0])
 	return restarter.restartCluster()
 }
 
 func (er *ElasticsearchRequest) PerformNodeUpdate(node NodeTypeInterface) error {
-	scheduledNode := []NodeTypeInterface{node}
+	return er.PerformNodesUpdate([]NodeTypeInterface{node})
+}
 
+// PerformNodesUpdate pushes the pending changes to the given nodes together. The upgrade
+// status of the first node drives the update phases and is mirrored to the rest of the nodes.
+func (er *ElasticsearchRequest) PerformNodesUpdate(scheduledNodes []NodeTypeInterface) error {
 	r := ClusterRestart{
 		log:              er.ll,
 		client:           er.esClient,
 		clusterName:      er.cluster.Name,
 		clusterNamespace: er.cluster.Namespace,
-		scheduledNodes:   scheduledNode,
+		scheduledNodes:   scheduledNodes,
 	}
 
 	restarter := Restarter{
 		log:              er.ll,
-		scheduledNodes:   scheduledNode,
+		scheduledNodes:   scheduledNodes,
 		clusterName:      er.cluster.Name,
 		clusterNamespace: er.cluster.Namespace,
 		precheck:         r.ensureClusterHealthValid,
@@ -219,20 +225,18 @@ func (er *ElasticsearchRequest) PerformNodeUpdate(node NodeTypeInterface) error
 	}
 
 	updateStatus := func() {
-		if err := er.setNodeStatus(node, restarter.nodeStatus, &er.cluster.Status); err != nil {
-			er.ll.Error(err, "unable to update node status")
-		}
+		er.setNodesUpgradeStatus(scheduledNodes, restarter.nodeStatus)
 	}
 
 	restarter.setNodeConditions(updateStatus)
 
-	restarter.nodeStatus = er.getNodeState(node)
+	restarter.nodeStatus = er.getNodeState(scheduledNodes[0])
 	return restarter.restartCluster()
 }
 
 func (er *ElasticsearchRequest) PerformRollingUpdate(nodes []NodeTypeInterface) error {
-	for _, node := range nodes {
-		if err := er.PerformNodeUpdate(node); err != nil {
+	for _, batch := range er.getRollingBatches(nodes) {
+		if err := er.PerformNodesUpdate(batch); err != nil {
 			return err
 		}
 	}
@@ -241,8 +245,8 @@ func (er *ElasticsearchRequest) PerformRollingUpdate(nodes []NodeTypeInterface)
 }
 
 func (er *ElasticsearchRequest) PerformRollingRestart(nodes []NodeTypeInterface) error {
-	for _, node := range nodes {
-		if err := er.PerformNodeRestart(node); err != nil {
+	for _, batch := range er.getRollingBatches(nodes) {
+		if err := er.PerformNodesRestart(batch); err != nil {
 			return err
 		}
 	}
@@ -250,6 +254,91 @@ func (er *ElasticsearchRequest) PerformRollingRestart(nodes []NodeTypeInterface)
 	return nil
 }
 
+// setNodesUpgradeStatus stores the status of the first node and copies its
+// upgrade status to the remaining nodes restarted along with it
+func (er *ElasticsearchRequest) setNodesUpgradeStatus(nodes []NodeTypeInterface, status *api.ElasticsearchNodeStatus) {
+	for i, node := range nodes {
+		nodeStatus := status
+		if i > 0 {
+			nodeStatus = er.getNodeState(node)
+			nodeStatus.UpgradeStatus = status.UpgradeStatus
+		}
+
+		if err := er.setNodeStatus(node, nodeStatus, &er.cluster.Status); err != nil {
+			er.ll.Error(err, "unable to update node status", "node", node.name())
+		}
+	}
+}
+
+// getRollingBatches splits the nodes into groups that can be restarted together.
+// Non-data nodes are always restarted one at a time. Data nodes are grouped by
+// their zone and each group is limited to maxUnavailable nodes.
+func (er *ElasticsearchRequest) getRollingBatches(nodes []NodeTypeInterface) [][]NodeTypeInterface {
+	batches := [][]NodeTypeInterface{}
+	batchSize := er.getMaxUnavailable()
+
+	zones := []string{}
+	zoneNodes := map[string][]NodeTypeInterface{}
+
+	for _, node := range nodes {
+		if _, ok := node.(*deploymentNode); !ok || batchSize == 1 {
+			batches = append(batches, []NodeTypeInterface{node})
+			continue
+		}
+
+		zone := getNodeZone(node)
+		if _, ok := zoneNodes[zone]; !ok {
+			zones = append(zones, zone)
+		}
+		zoneNodes[zone] = append(zoneNodes[zone], node)
+	}
+
+	for _, zone := range zones {
+		members := zoneNodes[zone]
+		for len(members) > batchSize {
+			batches = append(batches, members[:batchSize])
+			members = members[batchSize:]
+		}
+		batches = append(batches, members)
+	}
+
+	return batches
+}
+
+// getMaxUnavailable returns the number of data nodes that can be restarted together
+// without losing all copies of a shard, bounded by spec.maxUnavailable
+func (er *ElasticsearchRequest) getMaxUnavailable() int {
+	maxUnavailable := int(er.cluster.Spec.MaxUnavailable)
+
+	if replicas := CalculateReplicaCount(er.cluster); maxUnavailable > replicas {
+		maxUnavailable = replicas
+	}
+
+	if maxUnavailable < 1 {
+		return 1
+	}
+
+	return maxUnavailable
+}
+
+// getNodeZone returns the zone the node is pinned to through its node selector, if any
+func getNodeZone(node NodeTypeInterface) string {
+	var selector map[string]string
+
+	switch n := node.(type) {
+	case *deploymentNode:
+		selector = n.self.Spec.Template.Spec.NodeSelector
+	case *statefulSetNode:
+		selector = n.self.Spec.Template.Spec.NodeSelector
+	}
+
+	if zone, ok := selector[v1.LabelTopologyZone]; ok {
+		return zone
+	}
+
+	return selector[v1.LabelFailureDomainBetaZone]
+}
+
 // scaleDownThenUpFunc returns a func() error that uses the ElasticsearchRequest function AnyNodeReady
 // to determine if the cluster has any nodes running. If we use the NodeInterface function waitForNodeLeaveCluster
 // we may get stuck because we have no cluster nodes to query from.
@@ -492,8 +581,7 @@ func (r *Restarter) setNodeConditions(updateStatus func()) {
 	r.precheckSignaler = func() {
 		r.nodeStatus.UpgradeStatus.UnderUpgrade = v1.ConditionTrue
 
-		// for node restarts there should be only a single node
-		r.log.Info("Beginning restart of node", "node", r.scheduledNodes[0].name())
+		r.log.Info("Beginning restart of nodes", "nodes", nodeNames(r.scheduledNodes))
 		updateStatus()
 	}
 
@@ -516,8 +604,7 @@ func (r *Restarter) setNodeConditions(updateStatus func()) {
 	}
 
 	r.recoverySignaler = func() {
-		// for node restarts there should be only a single node
-		r.log.Info("Completed restart of node", "node", r.scheduledNodes[0].name())
+		r.log.Info("Completed restart of nodes", "nodes", nodeNames(r.scheduledNodes))
 
 		r.nodeStatus.UpgradeStatus.UpgradePhase = api.ControllerUpdated
 		r.nodeStatus.UpgradeStatus.UnderUpgrade = ""
@@ -528,6 +615,15 @@ func (r *Restarter) setNodeConditions(updateStatus func()) {
 	}
 }
 
+func nodeNames(nodes []NodeTypeInterface) []string {
+	names := []string{}
+	for _, node := range nodes {
+		names = append(names, node.name())
+	}
+
+	return names
+}
+
 // template function used for all restarts
 func (r Restarter) restartCluster() error {
 	if r.precheckCondition() {
@@ -2,24 +2,26 @@ package elasticsearch
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
 
 	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
 	"github.com/openshift/elasticsearch-operator/internal/constants"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/persistentvolume"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/pod"
 	"github.com/openshift/elasticsearch-operator/internal/utils"
+	"github.com/openshift/elasticsearch-operator/internal/utils/settings"
 
 	"github.com/ViaQ/logerr/v2/kverrors"
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
-	networking "k8s.io/api/networking/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -63,6 +65,54 @@ func getESImage() string {
 	return utils.LookupEnvWithDefault("RELATED_IMAGE_ELASTICSEARCH", constants.ElasticsearchDefaultImage)
 }
 
+var imageRepositoryVersionRegexp = regexp.MustCompile(`elasticsearch(\d+)`)
+
+// imageMajorVersion returns the major version from the image tag, e.g. 6 for elasticsearch6:6.8.1,
+// or from the image repository name when the image is referenced by digest, e.g. 6 for elasticsearch6-rhel8@sha256:...
+func imageMajorVersion(image string) (int, bool) {
+	repository := image[strings.LastIndex(image, "/")+1:]
+	if index := strings.Index(repository, "@"); index >= 0 {
+		repository = repository[:index]
+	}
+
+	if index := strings.LastIndex(repository, ":"); index >= 0 {
+		tag := strings.TrimPrefix(repository[index+1:], "v")
+		if major, err := strconv.Atoi(strings.SplitN(tag, ".", 2)[0]); err == nil {
+			return major, true
+		}
+		repository = repository[:index]
+	}
+
+	if matches := imageRepositoryVersionRegexp.FindStringSubmatch(repository); matches != nil {
+		if major, err := strconv.Atoi(matches[1]); err == nil {
+			return major, true
+		}
+	}
+
+	return 0, false
+}
+
+func isOpenSearchImage(image string) bool {
+	return strings.Contains(image[strings.LastIndex(image, "/")+1:], "opensearch")
+}
+
+// usesZenDiscovery returns true for Elasticsearch 6.x clusters which bootstrap with Zen discovery
+// and minimum_master_nodes. The major version is only taken from the image, never from the running
+// cluster, so that the rendered discovery settings do not depend on the cluster being reachable.
+// Images without a version are the 6.x default image. OpenSearch uses its own version numbers and
+// is only recognized by its image.
+func usesZenDiscovery(image string) bool {
+	if isOpenSearchImage(image) {
+		return false
+	}
+
+	if major, ok := imageMajorVersion(image); ok {
+		return major < 7
+	}
+
+	return true
+}
+
 func getESProxyImage() string {
 	return utils.LookupEnvWithDefault("RELATED_IMAGE_ELASTICSEARCH_PROXY", constants.ProxyDefaultImage)
 }
@@ -336,6 +386,79 @@ func newEnvVars(nodeName, clusterName, instanceRAM string, roleMap map[api.Elast
 	}
 }
 
+// newJVMSpec returns the JVM settings of the node, where every field
+// not set on the node is taken from the common spec
+func newJVMSpec(nodeJVM, commonJVM *api.ElasticsearchJVMSpec) api.ElasticsearchJVMSpec {
+	jvm := api.ElasticsearchJVMSpec{}
+	if commonJVM != nil {
+		jvm = *commonJVM.DeepCopy()
+	}
+
+	if nodeJVM == nil {
+		return jvm
+	}
+
+	if nodeJVM.HeapPercent != 0 || nodeJVM.HeapSize != nil {
+		jvm.HeapPercent = nodeJVM.HeapPercent
+		jvm.HeapSize = nodeJVM.HeapSize
+	}
+
+	if len(nodeJVM.Options) > 0 {
+		jvm.Options = nodeJVM.Options
+	}
+
+	return jvm
+}
+
+// getHeapSize returns the heap size for the memory limit of the Elasticsearch container,
+// rounded up to whole mebibytes. The heap size takes precedence over the heap percentage,
+// which defaults to 50 percent.
+func getHeapSize(memoryLimit resource.Quantity, jvm api.ElasticsearchJVMSpec) resource.Quantity {
+	var heap int64
+	if jvm.HeapSize != nil {
+		heap = jvm.HeapSize.Value()
+	} else {
+		percent := int64(jvm.HeapPercent)
+		if percent == 0 {
+			percent = defaultHeapPercent
+		}
+		heap = memoryLimit.Value() * percent / 100
+	}
+
+	return *resource.NewQuantity(heapMi(heap)*mebibyte, resource.BinarySI)
+}
+
+// heapMi returns the given number of bytes in mebibytes, rounded up
+func heapMi(bytes int64) int64 {
+	return (bytes + mebibyte - 1) / mebibyte
+}
+
+// newInstanceRAM returns the value for INSTANCE_RAM. The run script of the image appends
+// -Xms and -Xmx of half of INSTANCE_RAM after ES_JAVA_OPTS, and the last flag wins. With
+// custom heap settings the value is therefore twice the heap size, which exceeds the
+// memory limit for heaps above half of it. The image reads INSTANCE_RAM for the heap only.
+func newInstanceRAM(memoryLimit resource.Quantity, jvm api.ElasticsearchJVMSpec) string {
+	if jvm.HeapPercent == 0 && jvm.HeapSize == nil {
+		return memoryLimit.String()
+	}
+
+	heapSize := getHeapSize(memoryLimit, jvm)
+	return fmt.Sprintf("%dMi", 2*heapSize.Value()/mebibyte)
+}
+
+// newJavaOpts returns the value for ES_JAVA_OPTS. With custom heap settings the heap is
+// passed explicitly as well, matching the one the image derives from INSTANCE_RAM.
+func newJavaOpts(memoryLimit resource.Quantity, jvm api.ElasticsearchJVMSpec) string {
+	opts := []string{}
+	if jvm.HeapPercent != 0 || jvm.HeapSize != nil {
+		heapSize := getHeapSize(memoryLimit, jvm)
+		heap := heapSize.Value() / mebibyte
+		opts = append(opts, fmt.Sprintf("-Xms%dm", heap), fmt.Sprintf("-Xmx%dm", heap))
+	}
+
+	return strings.Join(append(opts, jvm.Options...), " ")
+}
+
 // TODO: add isChanged check for labels and label selector
 func newLabels(clusterName, nodeName string, roleMap map[api.ElasticsearchNodeRole]bool) map[string]string {
 	return map[string]string{
@@ -373,12 +496,37 @@ func newPodTemplateSpec(ctx context.Context, logger logr.Logger, nodeName, clust
 		},
 	})
 
+	jvm := newJVMSpec(node.JVM, commonSpec.JVM)
+	memoryLimit := resourceRequirements.Limits.Memory()
+	envVars := newEnvVars(nodeName, clusterName, newInstanceRAM(*memoryLimit, jvm), roleMap)
+	if javaOpts := newJavaOpts(*memoryLimit, jvm); javaOpts != "" {
+		envVars = append(envVars, v1.EnvVar{
+			Name:  "ES_JAVA_OPTS",
+			Value: javaOpts,
+		})
+	}
+
+	// config changes need a restart to be picked up, the hash changes the pod template
+	// so the node is scheduled for a rolling update
+	if hash := settings.Hash(mergeConfig(node.Config, commonSpec.Config)); hash != "" {
+		envVars = append(envVars, v1.EnvVar{
+			Name:  "ES_CONFIG_HASH",
+			Value: hash,
+		})
+	}
+
+	extraVolumes := appendVolumes(node.ExtraVolumes, commonSpec.ExtraVolumes)
+	extraVolumeMounts := appendVolumeMounts(node.ExtraVolumeMounts, commonSpec.ExtraVolumeMounts)
+
+	esContainer := newElasticsearchContainer(
+		getESImage(),
+		envVars,
+		resourceRequirements,
+	)
+	esContainer.VolumeMounts = append(esContainer.VolumeMounts, extraVolumeMounts...)
+
 	containers := []v1.Container{
-		newElasticsearchContainer(
-			getESImage(),
-			newEnvVars(nodeName, clusterName, resourceRequirements.Limits.Memory().String(), roleMap),
-			resourceRequirements,
-		),
+		esContainer,
 		newProxyContainer(
 			getESProxyImage(),
 			clusterName,
@@ -387,33 +535,202 @@ func newPodTemplateSpec(ctx context.Context, logger logr.Logger, nodeName, clust
 			proxyResourceRequirements,
 		),
 	}
+	containers = append(containers, appendContainers(node.Sidecars, commonSpec.Sidecars)...)
 
 	volumes := newVolumes(ctx, logger, clusterName, nodeName, namespace, node, client)
+	volumes = append(volumes, extraVolumes...)
+
+	priorityClassName := commonSpec.PriorityClassName
+	if node.PriorityClassName != "" {
+		priorityClassName = node.PriorityClassName
+	}
+
+	topologySpreadConstraints := commonSpec.TopologySpreadConstraints
+	if len(node.TopologySpreadConstraints) > 0 {
+		topologySpreadConstraints = node.TopologySpreadConstraints
+	}
+
+	customAffinity := commonSpec.Affinity
+	if node.Affinity != nil {
+		customAffinity = node.Affinity
+	}
 
 	podSpec := pod.NewSpec(clusterName, containers, volumes).
-		WithAffinity(newAffinity(roleMap)).
+		WithAffinity(mergeAffinity(customAffinity, newAffinity(roleMap))).
 		WithNodeSelectors(selectors).
 		WithTolerations(tolerations...).
+		WithPriorityClassName(priorityClassName).
+		WithTopologySpreadConstraints(topologySpreadConstraints...).
+		WithInitContainers(appendContainers(node.InitContainers, commonSpec.InitContainers)...).
 		WithSecurityContext(utils.PodSecurityContext()).
 		Build()
 
+	var annotations map[string]string
+	if len(commonSpec.PodAnnotations) > 0 || len(node.PodAnnotations) > 0 {
+		annotations = mergeConfig(node.PodAnnotations, commonSpec.PodAnnotations)
+	}
+
+	// the pod comparison only checks extra volumes and mounts by name, the hash changes the
+	// pod template whenever one of them is changed
+	if hash := extraVolumesHash(extraVolumes, extraVolumeMounts); hash != "" {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[extraVolumesHashAnnotation] = hash
+	}
+
 	return v1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
-			Labels: labels,
+			Labels:      newPodLabels(labels, commonSpec.PodLabels, node.PodLabels),
+			Annotations: annotations,
 		},
 		Spec: *podSpec,
 	}
 }
 
+// newPodLabels merges the custom pod labels of the node over the ones of the common spec.
+// The labels set by the operator take precedence, as they are used by selectors.
+func newPodLabels(labels, commonLabels, nodeLabels map[string]string) map[string]string {
+	if len(commonLabels) == 0 && len(nodeLabels) == 0 {
+		return labels
+	}
+
+	podLabels := map[string]string{}
+	for _, m := range []map[string]string{commonLabels, nodeLabels, labels} {
+		for k, v := range m {
+			podLabels[k] = v
+		}
+	}
+
+	return podLabels
+}
+
+// mergeAffinity adds the operator's pod anti-affinity to a copy of the custom affinity
+func mergeAffinity(custom, operator *v1.Affinity) *v1.Affinity {
+	if custom == nil {
+		return operator
+	}
+
+	affinity := custom.DeepCopy()
+	if affinity.PodAntiAffinity == nil {
+		affinity.PodAntiAffinity = &v1.PodAntiAffinity{}
+	}
+	affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+		affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		operator.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution...,
+	)
+
+	return affinity
+}
+
+// arePodTemplateSpecEqual compares the pod templates of a node. The pod comparison only checks that
+// the desired volumes are found by name, the extra volumes hash is compared as well so that
+// removed and changed extra volumes are rolled out.
+func arePodTemplateSpecEqual(current, desired v1.PodTemplateSpec) bool {
+	if current.Annotations[extraVolumesHashAnnotation] != desired.Annotations[extraVolumesHashAnnotation] {
+		return false
+	}
+
+	return pod.ArePodTemplateSpecEqual(current, desired)
+}
+
 // createUpdatablePodTemplateSpec creates a pod template from a copy of the update with
-// some aspects of the current
+// some aspects of the current. The storage volume is kept from the current, as storage
+// changes are not rolled out. The other volumes, including the config volume referencing
+// a node specific elasticsearch.yml and the extra volumes, are taken from the update.
 func createUpdatablePodTemplateSpec(current, desired v1.PodTemplateSpec) v1.PodTemplateSpec {
 	desiredCopy := desired
-	desiredCopy.Spec.Volumes = current.Spec.Volumes
+	desiredCopy.Spec.Volumes = []v1.Volume{}
+
+	var storageVolume *v1.Volume
+	for i, volume := range current.Spec.Volumes {
+		if volume.Name == "elasticsearch-storage" {
+			storageVolume = &current.Spec.Volumes[i]
+		}
+	}
+
+	for _, volume := range desired.Spec.Volumes {
+		if volume.Name == "elasticsearch-storage" && storageVolume != nil {
+			volume = *storageVolume
+		}
+		desiredCopy.Spec.Volumes = append(desiredCopy.Spec.Volumes, volume)
+	}
 
 	return desiredCopy
 }
 
+// isProxyImageOnlyChange returns true if the proxy image is the only difference of the two pod templates.
+// Only the image can be updated in place without restarting Elasticsearch, other proxy changes
+// recreate the pods and go through the rolling restart of the cluster.
+func isProxyImageOnlyChange(current, desired v1.PodTemplateSpec) bool {
+	currentProxy, _ := splitProxyContainer(current)
+	desiredProxy, _ := splitProxyContainer(desired)
+	if currentProxy == nil || desiredProxy == nil || currentProxy.Image == desiredProxy.Image {
+		return false
+	}
+
+	desiredCopy := *desired.DeepCopy()
+	for i, container := range desiredCopy.Spec.Containers {
+		if container.Name == "proxy" {
+			desiredCopy.Spec.Containers[i].Image = currentProxy.Image
+		}
+	}
+
+	return arePodTemplateSpecEqual(current, desiredCopy)
+}
+
+// splitProxyContainer returns the proxy container and a copy of the template without it
+func splitProxyContainer(template v1.PodTemplateSpec) (*v1.Container, v1.PodTemplateSpec) {
+	var proxy *v1.Container
+	others := *template.DeepCopy()
+	others.Spec.Containers = []v1.Container{}
+
+	for i, container := range template.Spec.Containers {
+		if container.Name == "proxy" {
+			proxy = &template.Spec.Containers[i]
+			continue
+		}
+		others.Spec.Containers = append(others.Spec.Containers, container)
+	}
+
+	return proxy, others
+}
+
+// updateProxyContainerImage sets the proxy image of the running pods matching the selector.
+// The image is the only container field that can be changed in place, this restarts only
+// the proxy container.
+func updateProxyContainerImage(ctx context.Context, c client.Client, namespace string, selector map[string]string, image string) error {
+	pods, err := pod.List(ctx, c, namespace, selector)
+	if err != nil {
+		return err
+	}
+
+	for i := range pods {
+		p := &pods[i]
+		changed := false
+
+		for j, container := range p.Spec.Containers {
+			if container.Name == "proxy" && container.Image != image {
+				p.Spec.Containers[j].Image = image
+				changed = true
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err := c.Update(ctx, p); err != nil {
+			return kverrors.Wrap(err, "failed to update proxy image of pod",
+				"pod", p.Name,
+				"namespace", namespace,
+			)
+		}
+	}
+
+	return nil
+}
+
 func newESResourceRequirements(nodeResRequirements, commonResRequirements v1.ResourceRequirements) v1.ResourceRequirements {
 	return newResourceRequirements(nodeResRequirements, commonResRequirements, defaultResources["elasticsearch"])
 }
@@ -575,11 +892,7 @@ func newVolumes(ctx context.Context, logger logr.Logger, clusterName, nodeName,
 		{
 			Name: "elasticsearch-config",
 			VolumeSource: v1.VolumeSource{
-				ConfigMap: &v1.ConfigMapVolumeSource{
-					LocalObjectReference: v1.LocalObjectReference{
-						Name: clusterName,
-					},
-				},
+				ConfigMap: newConfigVolumeSource(clusterName, node),
 			},
 		},
 		{
@@ -605,6 +918,128 @@ func newVolumes(ctx context.Context, logger logr.Logger, clusterName, nodeName,
 	}
 }
 
+// operatorVolumeNames returns the names of the volumes managed by the operator
+func operatorVolumeNames(clusterName string) []string {
+	return []string{
+		"elasticsearch-config",
+		"elasticsearch-storage",
+		"certificates",
+		fmt.Sprintf("%s-%s", clusterName, "metrics"),
+	}
+}
+
+// appendVolumes returns the common volumes with the node volumes merged over them by name
+func appendVolumes(nodeVolumes, commonVolumes []v1.Volume) []v1.Volume {
+	volumes := []v1.Volume{}
+	for _, volume := range commonVolumes {
+		if !containsVolume(nodeVolumes, volume.Name) {
+			volumes = append(volumes, volume)
+		}
+	}
+	return append(volumes, nodeVolumes...)
+}
+
+func containsVolume(volumes []v1.Volume, name string) bool {
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// appendVolumeMounts returns the common volume mounts with the node volume mounts merged over them by mount path
+func appendVolumeMounts(nodeMounts, commonMounts []v1.VolumeMount) []v1.VolumeMount {
+	mounts := []v1.VolumeMount{}
+	for _, mount := range commonMounts {
+		overridden := false
+		for _, nodeMount := range nodeMounts {
+			if nodeMount.MountPath == mount.MountPath {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			mounts = append(mounts, mount)
+		}
+	}
+	return append(mounts, nodeMounts...)
+}
+
+// extraVolumesHash returns a hash of the extra volumes and volume mounts, empty if there are none
+func extraVolumesHash(volumes []v1.Volume, mounts []v1.VolumeMount) string {
+	if len(volumes) == 0 && len(mounts) == 0 {
+		return ""
+	}
+
+	h := sha256.New()
+	_ = json.NewEncoder(h).Encode(volumes)
+	_ = json.NewEncoder(h).Encode(mounts)
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// appendContainers returns the common containers with the node containers merged over them by name.
+// The port protocols are defaulted as done by the API server, so they compare equal to the current ones.
+func appendContainers(nodeContainers, commonContainers []v1.Container) []v1.Container {
+	containers := []v1.Container{}
+	for _, container := range commonContainers {
+		overridden := false
+		for _, nodeContainer := range nodeContainers {
+			if nodeContainer.Name == container.Name {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			containers = append(containers, *container.DeepCopy())
+		}
+	}
+	for _, container := range nodeContainers {
+		containers = append(containers, *container.DeepCopy())
+	}
+
+	for i := range containers {
+		for j := range containers[i].Ports {
+			if containers[i].Ports[j].Protocol == "" {
+				containers[i].Ports[j].Protocol = v1.ProtocolTCP
+			}
+		}
+	}
+	return containers
+}
+
+// newConfigVolumeSource returns the elasticsearch configmap volume. Nodes with their own
+// config get the node specific elasticsearch.yml mounted in place of the common one.
+func newConfigVolumeSource(clusterName string, node api.ElasticsearchNode) *v1.ConfigMapVolumeSource {
+	source := &v1.ConfigMapVolumeSource{
+		LocalObjectReference: v1.LocalObjectReference{
+			Name: clusterName,
+		},
+	}
+
+	if len(node.Config) == 0 || node.GenUUID == nil {
+		return source
+	}
+
+	source.Items = []v1.KeyToPath{
+		{
+			Key:  nodeEsConfig(*node.GenUUID),
+			Path: esConfig,
+		},
+		{
+			Key:  log4jConfig,
+			Path: log4jConfig,
+		},
+		{
+			Key:  indexSettingsConfig,
+			Path: indexSettingsConfig,
+		},
+	}
+
+	return source
+}
+
 func newVolumeSource(ctx context.Context, logger logr.Logger, clusterName, nodeName, namespace string, node api.ElasticsearchNode, client client.Client) v1.VolumeSource {
 	specVol := node.Storage
 	volSource := v1.VolumeSource{}
@@ -654,130 +1089,3 @@ func newVolumeSource(ctx context.Context, logger logr.Logger, clusterName, nodeN
 	}
 	return volSource
 }
-
-/*
-kind: NetworkPolicy
-apiVersion: networking.k8s.io/v1
-metadata:
-
-	name: restricted-es-access
-
-spec:
-
-	podSelector:
-	  matchLabels:
-	    component: elasticsearch
-	ingress:
-	- from:
-	  - podSelector:
-	      matchLabels:
-	        name: elasticsearch-operator
-	  ports:
-	  - protocol: TCP
-	    port: 9200
-*/
-func newNetworkPolicy(namespace string) networking.NetworkPolicy {
-	protocol := v1.ProtocolTCP
-	port := intstr.FromInt(9200)
-	internalPort := intstr.FromInt(9300)
-
-	return networking.NetworkPolicy{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "NetworkPolicy",
-			APIVersion: networking.SchemeGroupVersion.String(),
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "restricted-es-policy",
-			Namespace: namespace,
-		},
-		Spec: networking.NetworkPolicySpec{
-			PodSelector: metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"component": "elasticsearch",
-				},
-			},
-			Ingress: []networking.NetworkPolicyIngressRule{
-				{
-					From: []networking.NetworkPolicyPeer{
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									"name": "elasticsearch-operator",
-								},
-							},
-							// This needs to be present but empty so it will select all namespaces
-							// since we do not have a label for our operator namespace
-							NamespaceSelector: &metav1.LabelSelector{},
-						},
-					},
-					Ports: []networking.NetworkPolicyPort{
-						{
-							Protocol: &protocol,
-							Port:     &port,
-						},
-					},
-				},
-				{
-					From: []networking.NetworkPolicyPeer{
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									"component": "elasticsearch",
-								},
-							},
-						},
-					},
-					Ports: []networking.NetworkPolicyPort{
-						{
-							Protocol: &protocol,
-							Port:     &port,
-						},
-					},
-				},
-				{
-					From: []networking.NetworkPolicyPeer{
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									"component": "elasticsearch",
-								},
-							},
-						},
-					},
-					Ports: []networking.NetworkPolicyPort{
-						{
-							Protocol: &protocol,
-							Port:     &internalPort,
-						},
-					},
-				},
-			},
-		},
-	}
-}
-
-func EnforceNetworkPolicy(namespace string, client client.Client, ownerRef []metav1.OwnerReference) error {
-	policy := newNetworkPolicy(namespace)
-	policy.ObjectMeta.OwnerReferences = ownerRef
-
-	err := client.Create(context.TODO(), &policy)
-	if err != nil {
-		if !apierrors.IsAlreadyExists(kverrors.Root(err)) {
-			return kverrors.Wrap(err, "failed to create network policy")
-		}
-	}
-
-	return nil
-}
-
-func RelaxNetworkPolicy(namespace string, client client.Client) error {
-	policy := newNetworkPolicy(namespace)
-	err := client.Delete(context.TODO(), &policy)
-	if err != nil {
-		if !apierrors.IsNotFound(kverrors.Root(err)) {
-			return kverrors.Wrap(err, "failed to delete network policy")
-		}
-	}
-
-	return nil
-}
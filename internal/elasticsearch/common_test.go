@@ -15,6 +15,7 @@ import (
 	"github.com/ViaQ/logerr/v2/log"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/ginkgo"
+	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -549,6 +550,389 @@ func TestProxyContainerSpec(t *testing.T) {
 	}
 }
 
+func TestNewJavaOpts(t *testing.T) {
+	memoryLimit := resource.MustParse("64Gi")
+	heapSize := resource.MustParse("31Gi")
+	smallHeapSize := resource.MustParse("100Ki")
+
+	tests := []struct {
+		desc string
+		jvm  api.ElasticsearchJVMSpec
+		want string
+	}{
+		{
+			desc: "image defaults",
+			want: "",
+		},
+		{
+			desc: "heap percent",
+			jvm:  api.ElasticsearchJVMSpec{HeapPercent: 25},
+			want: "-Xms16384m -Xmx16384m",
+		},
+		{
+			desc: "heap percent above half of the memory limit",
+			jvm:  api.ElasticsearchJVMSpec{HeapPercent: 75},
+			want: "-Xms49152m -Xmx49152m",
+		},
+		{
+			desc: "heap size takes precedence",
+			jvm:  api.ElasticsearchJVMSpec{HeapPercent: 25, HeapSize: &heapSize},
+			want: "-Xms31744m -Xmx31744m",
+		},
+		{
+			desc: "heap and jvm options",
+			jvm:  api.ElasticsearchJVMSpec{HeapPercent: 25, Options: []string{"-XX:+UseG1GC"}},
+			want: "-Xms16384m -Xmx16384m -XX:+UseG1GC",
+		},
+		{
+			desc: "heap rounded up to whole mebibytes",
+			jvm:  api.ElasticsearchJVMSpec{HeapSize: &smallHeapSize},
+			want: "-Xms1m -Xmx1m",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			if got := newJavaOpts(memoryLimit, test.jvm); got != test.want {
+				t.Errorf("Exp. ES_JAVA_OPTS to be %q but was %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestNewInstanceRAM(t *testing.T) {
+	memoryLimit := resource.MustParse("64Gi")
+	heapSize := resource.MustParse("31Gi")
+
+	tests := []struct {
+		desc string
+		jvm  api.ElasticsearchJVMSpec
+		want string
+	}{
+		{
+			desc: "image defaults",
+			want: "64Gi",
+		},
+		{
+			desc: "heap percent",
+			jvm:  api.ElasticsearchJVMSpec{HeapPercent: 25},
+			want: "32768Mi",
+		},
+		{
+			desc: "heap percent above half of the memory limit",
+			jvm:  api.ElasticsearchJVMSpec{HeapPercent: 75},
+			want: "98304Mi",
+		},
+		{
+			desc: "heap size takes precedence",
+			jvm:  api.ElasticsearchJVMSpec{HeapPercent: 25, HeapSize: &heapSize},
+			want: "63488Mi",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			if got := newInstanceRAM(memoryLimit, test.jvm); got != test.want {
+				t.Errorf("Exp. INSTANCE_RAM to be %s but was %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestIsProxyImageOnlyChange(t *testing.T) {
+	current := newPodTemplateSpec(context.Background(), log.NewLogger("common-testing"), "test-node-name", "test-cluster-name", "test-namespace-name", api.ElasticsearchNode{}, api.ElasticsearchNodeSpec{}, map[string]string{}, map[api.ElasticsearchNodeRole]bool{}, nil, LogConfig{})
+
+	withProxy := func(mutate func(*v1.Container)) v1.PodTemplateSpec {
+		template := *current.DeepCopy()
+		for i, container := range template.Spec.Containers {
+			if container.Name == "proxy" {
+				mutate(&template.Spec.Containers[i])
+			}
+		}
+		return template
+	}
+
+	tests := []struct {
+		desc    string
+		desired v1.PodTemplateSpec
+		want    bool
+	}{
+		{
+			desc:    "no changes",
+			desired: *current.DeepCopy(),
+			want:    false,
+		},
+		{
+			desc: "proxy image changed",
+			desired: withProxy(func(c *v1.Container) {
+				c.Image = "proxy:new"
+			}),
+			want: true,
+		},
+		{
+			desc: "proxy image and elasticsearch changed",
+			desired: func() v1.PodTemplateSpec {
+				template := withProxy(func(c *v1.Container) {
+					c.Image = "proxy:new"
+				})
+				template.Spec.Containers[0].Image = "elasticsearch:new"
+				return template
+			}(),
+			want: false,
+		},
+		{
+			desc: "proxy resources changed",
+			desired: withProxy(func(c *v1.Container) {
+				c.Resources.Limits = v1.ResourceList{v1.ResourceMemory: resource.MustParse("512Mi")}
+			}),
+			want: false,
+		},
+		{
+			desc: "proxy image and resources changed",
+			desired: withProxy(func(c *v1.Container) {
+				c.Image = "proxy:new"
+				c.Resources.Limits = v1.ResourceList{v1.ResourceMemory: resource.MustParse("512Mi")}
+			}),
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			if got := isProxyImageOnlyChange(current, test.desired); got != test.want {
+				t.Errorf("Exp. proxy image only change to be %t but was %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDeploymentProxyResourcesChangeSchedulesRollingRestart(t *testing.T) {
+	current := newPodTemplateSpec(context.Background(), log.NewLogger("common-testing"), "test-node-name", "test-cluster-name", "test-namespace-name", api.ElasticsearchNode{}, api.ElasticsearchNodeSpec{}, map[string]string{}, map[api.ElasticsearchNodeRole]bool{}, nil, LogConfig{})
+	desired := newPodTemplateSpec(context.Background(), log.NewLogger("common-testing"), "test-node-name", "test-cluster-name", "test-namespace-name", api.ElasticsearchNode{
+		ProxyResources: v1.ResourceRequirements{
+			Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+	}, api.ElasticsearchNodeSpec{}, map[string]string{}, map[api.ElasticsearchNodeRole]bool{}, nil, LogConfig{})
+
+	dpl := &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node-name", Namespace: "test-namespace-name"},
+		Spec:       apps.DeploymentSpec{Paused: true, Template: current},
+	}
+	k8sClient := fake.NewFakeClient(dpl)
+
+	node := &deploymentNode{
+		log:         log.NewLogger("common-testing"),
+		clusterName: "test-cluster-name",
+		client:      k8sClient,
+		self: apps.Deployment{
+			ObjectMeta: dpl.ObjectMeta,
+			Spec:       apps.DeploymentSpec{Paused: true, Template: desired},
+		},
+	}
+
+	if err := node.progressProxyChanges(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got := &apps.Deployment{}
+	if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: dpl.Name, Namespace: dpl.Namespace}, got); err != nil {
+		t.Fatalf("failed to get deployment: %s", err)
+	}
+	if !arePodTemplateSpecEqual(got.Spec.Template, current) {
+		t.Error("Exp. the proxy resources not to be rolled out in place")
+	}
+	if state := node.state(); state.UpgradeStatus.ScheduledForUpgrade != v1.ConditionTrue {
+		t.Error("Exp. the node to be scheduled for the rolling restart")
+	}
+}
+
+func TestPodTemplateSpecCustomization(t *testing.T) {
+	commonSpec := api.ElasticsearchNodeSpec{
+		PodLabels:         map[string]string{"team": "logging", "cluster-name": "other"},
+		PodAnnotations:    map[string]string{"backup": "disabled"},
+		PriorityClassName: "common-priority",
+		TopologySpreadConstraints: []v1.TopologySpreadConstraint{
+			{MaxSkew: 1, TopologyKey: v1.LabelTopologyZone, WhenUnsatisfiable: v1.ScheduleAnyway},
+		},
+	}
+	node := api.ElasticsearchNode{
+		PodAnnotations:    map[string]string{"backup": "enabled"},
+		PriorityClassName: "node-priority",
+		Affinity: &v1.Affinity{
+			NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "disk", Operator: v1.NodeSelectorOpIn, Values: []string{"ssd"}}}},
+					},
+				},
+			},
+		},
+	}
+	roleMap := map[api.ElasticsearchNodeRole]bool{api.ElasticsearchRoleData: true}
+	labels := map[string]string{"cluster-name": "test-cluster-name"}
+
+	template := newPodTemplateSpec(context.Background(), log.NewLogger("common-testing"), "test-node-name", "test-cluster-name", "test-namespace-name", node, commonSpec, labels, roleMap, nil, LogConfig{})
+
+	wantLabels := map[string]string{"team": "logging", "cluster-name": "test-cluster-name"}
+	if diff := cmp.Diff(wantLabels, template.Labels); diff != "" {
+		t.Errorf("pod labels mismatch: %s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"backup": "enabled"}, template.Annotations); diff != "" {
+		t.Errorf("pod annotations mismatch: %s", diff)
+	}
+	if template.Spec.PriorityClassName != "node-priority" {
+		t.Errorf("Exp. the node priority class but was %q", template.Spec.PriorityClassName)
+	}
+	if diff := cmp.Diff(commonSpec.TopologySpreadConstraints, template.Spec.TopologySpreadConstraints); diff != "" {
+		t.Errorf("topology spread constraints mismatch: %s", diff)
+	}
+	if diff := cmp.Diff(node.Affinity.NodeAffinity, template.Spec.Affinity.NodeAffinity); diff != "" {
+		t.Errorf("node affinity mismatch: %s", diff)
+	}
+	if diff := cmp.Diff(newAffinity(roleMap).PodAntiAffinity, template.Spec.Affinity.PodAntiAffinity); diff != "" {
+		t.Errorf("Exp. the operator anti-affinity to be kept: %s", diff)
+	}
+	if node.Affinity.PodAntiAffinity != nil {
+		t.Errorf("Exp. the node affinity of the spec not to be modified")
+	}
+}
+
+func TestPodTemplateSpecExtensions(t *testing.T) {
+	commonSpec := api.ElasticsearchNodeSpec{
+		ExtraVolumes:      []v1.Volume{{Name: "trust-bundle"}, {Name: "synonyms"}},
+		ExtraVolumeMounts: []v1.VolumeMount{{Name: "trust-bundle", MountPath: "/etc/pki/custom"}},
+		Sidecars:          []v1.Container{{Name: "log-shipper", Image: "shipper:common", Ports: []v1.ContainerPort{{ContainerPort: 2020}}}},
+	}
+	node := api.ElasticsearchNode{
+		ExtraVolumes:   []v1.Volume{{Name: "synonyms", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}},
+		InitContainers: []v1.Container{{Name: "fetch-synonyms", Image: "fetcher"}},
+		Sidecars:       []v1.Container{{Name: "log-shipper", Image: "shipper:node"}},
+	}
+
+	template := newPodTemplateSpec(context.Background(), log.NewLogger("common-testing"), "test-node-name", "test-cluster-name", "test-namespace-name", node, commonSpec, map[string]string{}, map[api.ElasticsearchNodeRole]bool{}, nil, LogConfig{})
+
+	volumes := template.Spec.Volumes[len(template.Spec.Volumes)-2:]
+	wantVolumes := []v1.Volume{commonSpec.ExtraVolumes[0], node.ExtraVolumes[0]}
+	if diff := cmp.Diff(wantVolumes, volumes); diff != "" {
+		t.Errorf("extra volumes mismatch: %s", diff)
+	}
+
+	esContainer := template.Spec.Containers[0]
+	if diff := cmp.Diff(commonSpec.ExtraVolumeMounts[0], esContainer.VolumeMounts[len(esContainer.VolumeMounts)-1]); diff != "" {
+		t.Errorf("extra volume mount mismatch: %s", diff)
+	}
+
+	if len(template.Spec.Containers) != 3 || template.Spec.Containers[2].Image != "shipper:node" {
+		t.Errorf("Exp. the node sidecar to be added after the operator containers, got %v", template.Spec.Containers)
+	}
+	if diff := cmp.Diff(node.InitContainers, template.Spec.InitContainers); diff != "" {
+		t.Errorf("init containers mismatch: %s", diff)
+	}
+}
+
+func TestPodTemplateSpecExtraVolumesChange(t *testing.T) {
+	newTemplate := func(volumes []v1.Volume, mounts []v1.VolumeMount) v1.PodTemplateSpec {
+		commonSpec := api.ElasticsearchNodeSpec{ExtraVolumes: volumes, ExtraVolumeMounts: mounts}
+		return newPodTemplateSpec(context.Background(), log.NewLogger("common-testing"), "test-node-name", "test-cluster-name", "test-namespace-name", api.ElasticsearchNode{}, commonSpec, map[string]string{}, map[api.ElasticsearchNodeRole]bool{}, nil, LogConfig{})
+	}
+
+	synonyms := v1.Volume{Name: "synonyms", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "synonyms"}}}}
+	synonymsMount := v1.VolumeMount{Name: "synonyms", MountPath: "/usr/share/elasticsearch/config/analysis"}
+	trustBundle := v1.Volume{Name: "trust-bundle", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "trust-bundle"}}}
+	trustBundleMount := v1.VolumeMount{Name: "trust-bundle", MountPath: "/etc/pki/custom"}
+
+	current := newTemplate([]v1.Volume{synonyms, trustBundle}, []v1.VolumeMount{synonymsMount, trustBundleMount})
+
+	changedSynonyms := *synonyms.DeepCopy()
+	changedSynonyms.ConfigMap.Name = "synonyms-v2"
+
+	tests := []struct {
+		desc    string
+		desired v1.PodTemplateSpec
+		want    bool
+	}{
+		{
+			desc:    "no changes",
+			desired: newTemplate([]v1.Volume{synonyms, trustBundle}, []v1.VolumeMount{synonymsMount, trustBundleMount}),
+			want:    true,
+		},
+		{
+			desc:    "extra volume removed",
+			desired: newTemplate([]v1.Volume{synonyms}, []v1.VolumeMount{synonymsMount}),
+			want:    false,
+		},
+		{
+			desc:    "all extra volumes removed",
+			desired: newTemplate(nil, nil),
+			want:    false,
+		},
+		{
+			desc:    "extra volume source changed",
+			desired: newTemplate([]v1.Volume{changedSynonyms, trustBundle}, []v1.VolumeMount{synonymsMount, trustBundleMount}),
+			want:    false,
+		},
+		{
+			desc:    "extra volume mount removed",
+			desired: newTemplate([]v1.Volume{synonyms, trustBundle}, []v1.VolumeMount{synonymsMount}),
+			want:    false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			if got := arePodTemplateSpecEqual(current, test.desired); got != test.want {
+				t.Errorf("Exp. pod templates equal to be %t but was %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestPodTemplateSpecSidecarPortProtocol(t *testing.T) {
+	commonSpec := api.ElasticsearchNodeSpec{
+		Sidecars: []v1.Container{{Name: "log-shipper", Ports: []v1.ContainerPort{{ContainerPort: 2020}}}},
+	}
+
+	template := newPodTemplateSpec(context.Background(), log.NewLogger("common-testing"), "test-node-name", "test-cluster-name", "test-namespace-name", api.ElasticsearchNode{}, commonSpec, map[string]string{}, map[api.ElasticsearchNodeRole]bool{}, nil, LogConfig{})
+
+	if got := template.Spec.Containers[2].Ports[0].Protocol; got != v1.ProtocolTCP {
+		t.Errorf("Exp. the sidecar port protocol to be defaulted to TCP but was %q", got)
+	}
+	if commonSpec.Sidecars[0].Ports[0].Protocol != "" {
+		t.Errorf("Exp. the sidecar of the spec not to be modified")
+	}
+}
+
+func TestCreateUpdatablePodTemplateSpec(t *testing.T) {
+	current := v1.PodTemplateSpec{
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{Name: "elasticsearch-config"},
+				{Name: "elasticsearch-storage", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "current"}}},
+				{Name: "removed-extra"},
+			},
+		},
+	}
+	desired := v1.PodTemplateSpec{
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{Name: "elasticsearch-config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{}}},
+				{Name: "elasticsearch-storage", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+				{Name: "added-extra"},
+			},
+		},
+	}
+
+	want := []v1.Volume{desired.Spec.Volumes[0], current.Spec.Volumes[1], desired.Spec.Volumes[2]}
+	got := createUpdatablePodTemplateSpec(current, desired)
+	if diff := cmp.Diff(want, got.Spec.Volumes); diff != "" {
+		t.Errorf("volumes mismatch: %s", diff)
+	}
+}
+
 func TestPodSpecHasTaintTolerations(t *testing.T) {
 	expectedTolerations := []v1.Toleration{
 		{
@@ -903,3 +1287,46 @@ var _ = Describe("common.go", func() {
 		})
 	})
 })
+
+func TestUsesZenDiscovery(t *testing.T) {
+	tests := []struct {
+		desc  string
+		image string
+		want  bool
+	}{
+		{
+			desc:  "elasticsearch 6 tag",
+			image: "quay.io/openshift-logging/elasticsearch6:6.8.1",
+			want:  true,
+		},
+		{
+			desc:  "elasticsearch 7 tag",
+			image: "registry.example.com:5000/logging/elasticsearch:v7.16.3",
+			want:  false,
+		},
+		{
+			desc:  "elasticsearch 6 digest",
+			image: "registry.redhat.io/openshift-logging/elasticsearch6-rhel8@sha256:0123456789abcdef",
+			want:  true,
+		},
+		{
+			desc:  "elasticsearch 7 repository with non version tag",
+			image: "quay.io/openshift-logging/elasticsearch7:latest",
+			want:  false,
+		},
+		{
+			desc:  "opensearch",
+			image: "docker.io/opensearchproject/opensearch:2.11.0",
+			want:  false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			if got := usesZenDiscovery(test.image); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
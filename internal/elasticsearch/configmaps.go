@@ -4,13 +4,20 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"fmt"
 	"html/template"
 	"io"
+	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ViaQ/logerr/v2/kverrors"
+	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/configmap"
+	"github.com/openshift/elasticsearch-operator/internal/utils/settings"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -18,8 +25,33 @@ const (
 	esConfig            = "elasticsearch.yml"
 	log4jConfig         = "log4j2.properties"
 	indexSettingsConfig = "index_settings"
+	transportCABundle   = "transport-ca.crt"
 )
 
+// configPolicy protects the elasticsearch.yml settings owned by the operator,
+// which cannot be overridden through the config of the custom resource
+var configPolicy = settings.Policy{
+	Kind: "elasticsearch.yml setting",
+	Protected: []string{
+		"cluster.name",
+		"cluster.initial_master_nodes",
+		"bootstrap",
+		"node.name",
+		"node.master",
+		"node.data",
+		"node.max_local_storage_nodes",
+		"action.auto_create_index",
+		"network",
+		"discovery",
+		"gateway",
+		"path",
+		"prometheus",
+		"http.max_header_size",
+		"opendistro_security",
+		"xpack.security",
+	},
+}
+
 // esYmlStruct is used to render esYmlTmpl to a proper elasticsearch.yml format
 type esYmlStruct struct {
 	KibanaIndexMode      string
@@ -27,12 +59,22 @@ type esYmlStruct struct {
 	NodeQuorum           string
 	RecoverExpectedNodes string
 	SystemCallFilter     string
+	InitialMasterNodes   []string
+	TransportCABundle    string
 }
 
 type log4j2PropertiesStruct struct {
 	RootLogger       string
 	LogLevel         string
 	SecurityLogLevel string
+	SlowLogConsole   bool
+	Loggers          []log4j2Logger
+}
+
+type log4j2Logger struct {
+	ID    string
+	Name  string
+	Level string
 }
 
 type indexSettingsStruct struct {
@@ -52,6 +94,34 @@ func (er *ElasticsearchRequest) CreateOrUpdateConfigMaps() error {
 	masterNodeCount := int(getMasterCount(dpl))
 
 	logConfig := getLogConfig(dpl.GetAnnotations())
+	logConfig.SlowLogConsole = dpl.Spec.SlowLog != nil && dpl.Spec.SlowLog.Console
+	// keep the logger levels applied at runtime for new pods
+	now := time.Now()
+	logConfig.Loggers = activeLoggers(newLoggingStatus(dpl.Spec.Logging, dpl.Status.Logging, now), now)
+
+	// the initial master nodes and the node specific configs are named after the
+	// node GenUUIDs, make sure they are known before rendering them
+	if err := er.recoverOrphanedCluster(); err != nil {
+		return err
+	}
+	er.setUUIDs()
+
+	var initialMasterNodes []string
+	if !usesZenDiscovery(getESImage()) {
+		initialMasterNodes = getInitialMasterNodes(dpl)
+	}
+
+	caBundle, err := er.transportCABundle()
+	if err != nil {
+		return err
+	}
+
+	nodeConfigs := map[string]map[string]string{}
+	for _, node := range dpl.Spec.Nodes {
+		if len(node.Config) > 0 && node.GenUUID != nil {
+			nodeConfigs[*node.GenUUID] = mergeConfig(node.Config, dpl.Spec.Spec.Config)
+		}
+	}
 
 	cm := newConfigMap(
 		dpl.Name,
@@ -64,7 +134,11 @@ func (er *ElasticsearchRequest) CreateOrUpdateConfigMaps() error {
 		strconv.Itoa(CalculatePrimaryCount(dpl)),
 		strconv.Itoa(CalculateReplicaCount(dpl)),
 		strconv.FormatBool(runtime.GOARCH == "amd64"),
+		initialMasterNodes,
+		caBundle,
 		logConfig,
+		dpl.Spec.Spec.Config,
+		nodeConfigs,
 	)
 
 	dpl.AddOwnerRefTo(cm)
@@ -106,14 +180,22 @@ func (er *ElasticsearchRequest) CreateOrUpdateConfigMaps() error {
 	return nil
 }
 
-func renderData(kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes, primaryShardsCount, replicaShardsCount, systemCallFilter string, logConfig LogConfig) (map[string]string, error) {
+func renderData(kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes, primaryShardsCount, replicaShardsCount, systemCallFilter string, initialMasterNodes []string, caBundle string, logConfig LogConfig, config map[string]string, nodeConfigs map[string]map[string]string) (map[string]string, error) {
 	data := map[string]string{}
 	buf := &bytes.Buffer{}
-	if err := renderEsYml(buf, kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes, systemCallFilter); err != nil {
+	if err := renderEsYml(buf, kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes, systemCallFilter, initialMasterNodes, caBundle, config); err != nil {
 		return data, err
 	}
 	data[esConfig] = buf.String()
 
+	for uuid, nodeConfig := range nodeConfigs {
+		buf = &bytes.Buffer{}
+		if err := renderEsYml(buf, kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes, systemCallFilter, initialMasterNodes, caBundle, nodeConfig); err != nil {
+			return data, err
+		}
+		data[nodeEsConfig(uuid)] = buf.String()
+	}
+
 	buf = &bytes.Buffer{}
 	if err := renderLog4j2Properties(buf, logConfig); err != nil {
 		return data, err
@@ -126,13 +208,18 @@ func renderData(kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes
 	}
 	data[indexSettingsConfig] = buf.String()
 
+	if caBundle != "" {
+		data[transportCABundle] = caBundle
+	}
+
 	return data, nil
 }
 
 // newConfigMap returns a v1.ConfigMap object
 func newConfigMap(configMapName, namespace string, labels map[string]string,
-	kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes, primaryShardsCount, replicaShardsCount, systemCallFilter string, logConfig LogConfig) *v1.ConfigMap {
-	data, err := renderData(kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes, primaryShardsCount, replicaShardsCount, systemCallFilter, logConfig)
+	kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes, primaryShardsCount, replicaShardsCount, systemCallFilter string, initialMasterNodes []string,
+	caBundle string, logConfig LogConfig, config map[string]string, nodeConfigs map[string]map[string]string) *v1.ConfigMap {
+	data, err := renderData(kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes, primaryShardsCount, replicaShardsCount, systemCallFilter, initialMasterNodes, caBundle, logConfig, config, nodeConfigs)
 	if err != nil {
 		return nil
 	}
@@ -162,13 +249,52 @@ func configMapContentEqual(old, new *v1.ConfigMap) bool {
 		return false
 	}
 
+	if sha256.Sum256([]byte(old.Data[transportCABundle])) != sha256.Sum256([]byte(new.Data[transportCABundle])) {
+		return false
+	}
+
+	// compare the elasticsearch.yml of nodes with their own config
+	for _, data := range []map[string]string{old.Data, new.Data} {
+		for key := range data {
+			if !strings.HasPrefix(key, "elasticsearch-") {
+				continue
+			}
+
+			if sha256.Sum256([]byte(old.Data[key])) != sha256.Sum256([]byte(new.Data[key])) {
+				return false
+			}
+		}
+	}
+
 	return true
 }
 
-func renderEsYml(w io.Writer, kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes, systemCallFilter string) error {
+// nodeEsConfig returns the configmap key of the elasticsearch.yml for nodes with their own config
+func nodeEsConfig(uuid string) string {
+	return fmt.Sprintf("elasticsearch-%s.yml", uuid)
+}
+
+// mergeConfig returns a new map of the common config overridden by the node config
+func mergeConfig(nodeConfig, commonConfig map[string]string) map[string]string {
+	config := map[string]string{}
+
+	for k, v := range commonConfig {
+		config[k] = v
+	}
+
+	for k, v := range nodeConfig {
+		config[k] = v
+	}
+
+	return config
+}
+
+// renderEsYml renders the elasticsearch.yml. Clusters bootstrapped without Zen discovery (7.x and OpenSearch)
+// are given the initialMasterNodes, Zen discovery is rendered when they are empty. The transport layer
+// trusts the PEM CA bundle instead of the truststore when a bundle is given.
+func renderEsYml(w io.Writer, kibanaIndexMode, esUnicastHost, nodeQuorum, recoverExpectedNodes, systemCallFilter string, initialMasterNodes []string, caBundle string, config map[string]string) error {
 	t := template.New("elasticsearch.yml")
-	config := esYmlTmpl
-	t, err := t.Parse(config)
+	t, err := t.Parse(esYmlTmpl)
 	if err != nil {
 		return err
 	}
@@ -178,9 +304,31 @@ func renderEsYml(w io.Writer, kibanaIndexMode, esUnicastHost, nodeQuorum, recove
 		NodeQuorum:           nodeQuorum,
 		RecoverExpectedNodes: recoverExpectedNodes,
 		SystemCallFilter:     systemCallFilter,
+		InitialMasterNodes:   initialMasterNodes,
+	}
+	if caBundle != "" {
+		esy.TransportCABundle = elasticsearchConfigPath + "/" + transportCABundle
 	}
 
-	return t.Execute(w, esy)
+	if err := t.Execute(w, esy); err != nil {
+		return err
+	}
+
+	return renderConfigOverrides(w, config)
+}
+
+// renderConfigOverrides appends the user provided settings.
+// Protected settings are skipped, they are rejected by the config validation.
+func renderConfigOverrides(w io.Writer, config map[string]string) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	if _, err := io.WriteString(w, "\n\n# settings from the custom resource config\n"); err != nil {
+		return err
+	}
+
+	return configPolicy.Render(w, config)
 }
 
 func renderLog4j2Properties(w io.Writer, logConfig LogConfig) error {
@@ -194,11 +342,36 @@ func renderLog4j2Properties(w io.Writer, logConfig LogConfig) error {
 		RootLogger:       logConfig.ServerAppender,
 		LogLevel:         logConfig.ServerLoglevel,
 		SecurityLogLevel: logConfig.LogLevel,
+		SlowLogConsole:   logConfig.SlowLogConsole,
+		Loggers:          newLog4j2Loggers(logConfig.Loggers),
 	}
 
 	return t.Execute(w, log4jProp)
 }
 
+var log4j2LoggerIDRegexp = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+// newLog4j2Loggers returns the loggers sorted by name with an ID
+// which does not clash with the loggers of the template
+func newLog4j2Loggers(loggers map[string]api.LogLevel) []log4j2Logger {
+	names := make([]string, 0, len(loggers))
+	for name := range loggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := []log4j2Logger{}
+	for _, name := range names {
+		result = append(result, log4j2Logger{
+			ID:    "spec_" + log4j2LoggerIDRegexp.ReplaceAllString(name, "_"),
+			Name:  name,
+			Level: string(loggers[name]),
+		})
+	}
+
+	return result
+}
+
 func renderIndexSettings(w io.Writer, primaryShardsCount, replicaShardsCount string) error {
 	t := template.New("index_settings")
 	t, err := t.Parse(indexSettingsTmpl)
@@ -2,11 +2,21 @@ package elasticsearch
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"os"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
 	"github.com/openshift/elasticsearch-operator/test/helpers"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 var _ = Describe("configmaps.go", func() {
@@ -16,7 +26,7 @@ var _ = Describe("configmaps.go", func() {
 	Describe("#renderLog4j2Properties", func() {
 		It("should create a well-formed file without error", func() {
 			out := bytes.NewBufferString("")
-			logConfig := LogConfig{"debug", "trace", "mylogger"}
+			logConfig := LogConfig{"debug", "trace", "mylogger", false, nil}
 			if err := renderLog4j2Properties(out, logConfig); err != nil {
 				Fail(fmt.Sprintf("unable to render Log4J properties. %s\r\n", err.Error()))
 			}
@@ -100,6 +110,32 @@ appender.index_indexing_slowlog_rolling.policies.time.modulate = true
 logger.index_indexing_slowlog.name = index.indexing.slowlog.index
 logger.index_indexing_slowlog.level = trace
 logger.index_indexing_slowlog.appenderRef.index_indexing_slowlog_rolling.ref = index_indexing_slowlog_rolling
+logger.index_indexing_slowlog.additivity = false`))
+		})
+
+		It("should set the levels of the loggers from the spec", func() {
+			out := bytes.NewBufferString("")
+			logConfig := LogConfig{"info", "info", "console", false, map[string]api.LogLevel{
+				"org.elasticsearch.discovery": api.LogLevelDebug,
+			}}
+			Expect(renderLog4j2Properties(out, logConfig)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring(`logger.security.level = info
+
+logger.spec_org_elasticsearch_discovery.name = org.elasticsearch.discovery
+logger.spec_org_elasticsearch_discovery.level = debug
+
+appender.console.type = Console`))
+		})
+
+		It("should write the slow logs to the console when requested", func() {
+			out := bytes.NewBufferString("")
+			logConfig := LogConfig{"info", "info", "console", true, nil}
+			Expect(renderLog4j2Properties(out, logConfig)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring(`logger.index_search_slowlog_rolling.level = trace
+logger.index_search_slowlog_rolling.appenderRef.console.ref = console
+logger.index_search_slowlog_rolling.additivity = false`))
+			Expect(out.String()).To(ContainSubstring(`logger.index_indexing_slowlog.level = trace
+logger.index_indexing_slowlog.appenderRef.console.ref = console
 logger.index_indexing_slowlog.additivity = false`))
 		})
 	})
@@ -111,7 +147,7 @@ var _ = Describe("configmaps", func() {
 	Describe("#renderEsYml", func() {
 		It("should produce an elasticsearch.yml for our managed elasticsearch instance", func() {
 			result := &bytes.Buffer{}
-			Expect(renderEsYml(result, "", "my.unicast.host", "7", "4", "false")).To(BeNil(), "Exp. no errors when rendering the configuration")
+			Expect(renderEsYml(result, "", "my.unicast.host", "7", "4", "false", nil, "", nil)).To(BeNil(), "Exp. no errors when rendering the configuration")
 			helpers.ExpectYaml(result.String()).ToEqual(`
 cluster:
   name: ${CLUSTER_NAME}
@@ -178,5 +214,117 @@ opendistro_security:
       truststore_filepath: /etc/elasticsearch/secret/truststore.p12
       truststore_password: tspass`)
 		})
+
+		It("should bootstrap clusters without Zen discovery from the initial master nodes", func() {
+			result := &bytes.Buffer{}
+			masters := []string{"elasticsearch-cdm-abc-1", "elasticsearch-m-xyz-0"}
+			Expect(renderEsYml(result, "", "my.unicast.host", "7", "4", "false", masters, "", nil)).To(BeNil(), "Exp. no errors when rendering the configuration")
+			Expect(result.String()).To(HavePrefix(`
+cluster:
+  name: ${CLUSTER_NAME}
+  initial_master_nodes:
+  - elasticsearch-cdm-abc-1
+  - elasticsearch-m-xyz-0
+
+bootstrap:`))
+			Expect(result.String()).To(ContainSubstring(`
+  bind_host: ["${POD_IP}",_local_]
+
+discovery.seed_hosts: my.unicast.host
+
+gateway:`))
+			Expect(result.String()).ToNot(ContainSubstring("discovery.zen"))
+		})
+
+		It("should append the settings from the config", func() {
+			result := &bytes.Buffer{}
+			config := map[string]string{
+				"thread_pool.write.queue_size": "1000",
+				"search.max_buckets":           "20000",
+				"node.attr.rack":               "rack: 1 # top",
+				"path.data":                    "/tmp",
+			}
+			Expect(renderEsYml(result, "", "my.unicast.host", "7", "4", "false", nil, "", config)).To(BeNil(), "Exp. no errors when rendering the configuration")
+			Expect(result.String()).To(HaveSuffix(`
+# settings from the custom resource config
+node.attr.rack: "rack: 1 # top"
+search.max_buckets: 20000
+thread_pool.write.queue_size: 1000
+`))
+		})
+
+		It("should trust the transport CA bundle when given", func() {
+			result := &bytes.Buffer{}
+			Expect(renderEsYml(result, "", "my.unicast.host", "7", "4", "false", nil, "app-ca\ninfra-ca\n", nil)).To(BeNil(), "Exp. no errors when rendering the configuration")
+			Expect(result.String()).To(ContainSubstring(`
+    transport:
+      enabled: true
+      enforce_hostname_verification: false
+      pemcert_filepath: /etc/openshift/elasticsearch/secret/elasticsearch.crt
+      pemkey_filepath: /etc/openshift/elasticsearch/secret/elasticsearch.key
+      pemtrustedcas_filepath: /usr/share/java/elasticsearch/config/transport-ca.crt
+    http:`))
+			Expect(result.String()).ToNot(ContainSubstring("searchguard-truststore.p12"))
+		})
+	})
+
+	Describe("#configPolicy.Validate", func() {
+		It("should allow settings not owned by the operator", func() {
+			Expect(configPolicy.Validate(map[string]string{
+				"indices.memory.index_buffer_size": "20%",
+				"node.attr.zone":                   "zone-a",
+			})).To(Succeed())
+		})
+
+		It("should reject settings owned by the operator", func() {
+			Expect(configPolicy.Validate(map[string]string{"discovery.zen.minimum_master_nodes": "1"})).NotTo(Succeed())
+			Expect(configPolicy.Validate(map[string]string{"opendistro_security": "{}"})).NotTo(Succeed())
+			Expect(configPolicy.Validate(map[string]string{"node.master": "false"})).NotTo(Succeed())
+		})
+
+		It("should reject malformed setting names", func() {
+			Expect(configPolicy.Validate(map[string]string{"search.max_buckets: 1\npath.data": "/tmp"})).NotTo(Succeed())
+		})
+	})
+
+	Describe("#configMapContentEqual", func() {
+		It("should detect changes to node specific configs", func() {
+			old := &v1.ConfigMap{Data: map[string]string{esConfig: "a", nodeEsConfig("deadbeef"): "b"}}
+			new := &v1.ConfigMap{Data: map[string]string{esConfig: "a", nodeEsConfig("deadbeef"): "c"}}
+			Expect(configMapContentEqual(old, new)).To(BeFalse())
+			Expect(configMapContentEqual(old, old)).To(BeTrue())
+		})
+	})
+
+	Describe("#CreateOrUpdateConfigMaps", func() {
+		It("should render the node specific configs with zen discovery", func() {
+			utilruntime.Must(api.AddToScheme(scheme.Scheme))
+			Expect(os.Setenv("RELATED_IMAGE_ELASTICSEARCH", "quay.io/openshift/origin-logging-elasticsearch6:latest")).To(Succeed())
+			defer os.Unsetenv("RELATED_IMAGE_ELASTICSEARCH")
+
+			cluster := &api.Elasticsearch{
+				ObjectMeta: metav1.ObjectMeta{Name: "elasticsearch", Namespace: "openshift-logging"},
+				Spec: api.ElasticsearchSpec{
+					Nodes: []api.ElasticsearchNode{
+						{
+							Roles:     []api.ElasticsearchNodeRole{api.ElasticsearchRoleMaster, api.ElasticsearchRoleData},
+							NodeCount: 1,
+							Config:    map[string]string{"node.attr.zone": "zone-a"},
+						},
+					},
+				},
+			}
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cluster).Build()
+			er := &ElasticsearchRequest{client: k8sClient, cluster: cluster, ll: log.Log}
+
+			Expect(er.CreateOrUpdateConfigMaps()).To(Succeed())
+			Expect(cluster.Spec.Nodes[0].GenUUID).ToNot(BeNil())
+
+			cm := &v1.ConfigMap{}
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Name: "elasticsearch", Namespace: "openshift-logging"}, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKey(nodeEsConfig(*cluster.Spec.Nodes[0].GenUUID)))
+			Expect(cm.Data[nodeEsConfig(*cluster.Spec.Nodes[0].GenUUID)]).To(ContainSubstring("node.attr.zone: zone-a"))
+			Expect(cm.Data[esConfig]).To(ContainSubstring("discovery.zen"))
+		})
 	})
 })
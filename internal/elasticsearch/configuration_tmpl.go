@@ -3,6 +3,12 @@ package elasticsearch
 const esYmlTmpl = `
 cluster:
   name: ${CLUSTER_NAME}
+{{- if .InitialMasterNodes}}
+  initial_master_nodes:
+{{- range .InitialMasterNodes}}
+  - {{.}}
+{{- end}}
+{{- end}}
 
 bootstrap:
   system_call_filter: {{.SystemCallFilter}}
@@ -19,9 +25,13 @@ network:
   publish_host: ${POD_IP}
   bind_host: ["${POD_IP}",_local_]
 
+{{if .InitialMasterNodes -}}
+discovery.seed_hosts: {{.EsUnicastHost}}
+{{- else -}}
 discovery.zen:
   ping.unicast.hosts: {{.EsUnicastHost}}
   minimum_master_nodes: {{.NodeQuorum}}
+{{- end}}
 
 gateway:
   recover_after_nodes: {{.NodeQuorum}}
@@ -50,12 +60,18 @@ opendistro_security:
     transport:
       enabled: true
       enforce_hostname_verification: false
+{{- if .TransportCABundle}}
+      pemcert_filepath: /etc/openshift/elasticsearch/secret/elasticsearch.crt
+      pemkey_filepath: /etc/openshift/elasticsearch/secret/elasticsearch.key
+      pemtrustedcas_filepath: {{.TransportCABundle}}
+{{- else}}
       keystore_type: PKCS12
       keystore_filepath: /etc/elasticsearch/secret/searchguard-key.p12
       keystore_password: kspass
       truststore_type: PKCS12
       truststore_filepath: /etc/elasticsearch/secret/searchguard-truststore.p12
       truststore_password: tspass
+{{- end}}
     http:
       enabled: true
       keystore_type: PKCS12
@@ -75,6 +91,11 @@ logger.action.level = debug
 
 logger.security.name = com.amazon.opendistroforelasticsearch.security
 logger.security.level = {{.SecurityLogLevel}}
+{{- range .Loggers}}
+
+logger.{{.ID}}.name = {{.Name}}
+logger.{{.ID}}.level = {{.Level}}
+{{- end}}
 
 appender.console.type = Console
 appender.console.name = console
@@ -129,7 +150,11 @@ appender.index_search_slowlog_rolling.policies.time.modulate = true
 
 logger.index_search_slowlog_rolling.name = index.search.slowlog
 logger.index_search_slowlog_rolling.level = trace
+{{- if .SlowLogConsole}}
+logger.index_search_slowlog_rolling.appenderRef.console.ref = console
+{{- else}}
 logger.index_search_slowlog_rolling.appenderRef.index_search_slowlog_rolling.ref = index_search_slowlog_rolling
+{{- end}}
 logger.index_search_slowlog_rolling.additivity = false
 
 appender.index_indexing_slowlog_rolling.type = RollingFile
@@ -145,7 +170,11 @@ appender.index_indexing_slowlog_rolling.policies.time.modulate = true
 
 logger.index_indexing_slowlog.name = index.indexing.slowlog.index
 logger.index_indexing_slowlog.level = trace
+{{- if .SlowLogConsole}}
+logger.index_indexing_slowlog.appenderRef.console.ref = console
+{{- else}}
 logger.index_indexing_slowlog.appenderRef.index_indexing_slowlog_rolling.ref = index_indexing_slowlog_rolling
+{{- end}}
 logger.index_indexing_slowlog.additivity = false`
 
 const indexSettingsTmpl = `
@@ -7,9 +7,12 @@ import (
 	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// GetElasticsearchCR returns the single Elasticsearch CR of the namespace. It fails when the
+// namespace holds several ones since none of them can be chosen over the others.
 func GetElasticsearchCR(c client.Client, ns string) (*loggingv1.Elasticsearch, error) {
 	esl := &loggingv1.ElasticsearchList{}
 	opts := &client.ListOptions{Namespace: ns}
@@ -32,5 +35,45 @@ func GetElasticsearchCR(c client.Client, ns string) (*loggingv1.Elasticsearch, e
 		return nil, apierrors.NewNotFound(gr, "elasticsearch")
 	}
 
+	if len(esl.Items) > 1 {
+		return nil, kverrors.New("multiple elasticsearch instances found in namespace, a reference is required",
+			"namespace", ns,
+			"count", len(esl.Items),
+		)
+	}
+
 	return &esl.Items[0], nil
 }
+
+// GetReferencedElasticsearchCR returns the Elasticsearch CR of the reference, looked up in ns
+// when the reference does not set a namespace. A cluster in another namespace than ns needs
+// to allow ns in its allowedKibanaNamespaces.
+func GetReferencedElasticsearchCR(c client.Client, ref *loggingv1.ElasticsearchReference, ns string) (*loggingv1.Elasticsearch, error) {
+	referrerNamespace := ns
+	if ref.Namespace != "" {
+		ns = ref.Namespace
+	}
+
+	es := &loggingv1.Elasticsearch{}
+	key := client.ObjectKey{Name: ref.Name, Namespace: ns}
+	if err := c.Get(context.TODO(), key, es); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		return nil, kverrors.Wrap(err, "unable to get elasticsearch instance",
+			"name", ref.Name,
+			"namespace", ns,
+		)
+	}
+
+	if es.Namespace != referrerNamespace && !slices.Contains(es.Spec.AllowedKibanaNamespaces, referrerNamespace) {
+		return nil, kverrors.New("elasticsearch instance does not allow kibana instances of the namespace",
+			"name", ref.Name,
+			"namespace", ns,
+			"kibana_namespace", referrerNamespace,
+		)
+	}
+
+	return es, nil
+}
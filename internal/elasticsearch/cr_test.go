@@ -0,0 +1,116 @@
+package elasticsearch
+
+import (
+	"testing"
+
+	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetElasticsearchCR(t *testing.T) {
+	utilruntime.Must(loggingv1.AddToScheme(scheme.Scheme))
+
+	newCR := func(name, namespace string) client.Object {
+		return &loggingv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+
+	tests := []struct {
+		desc     string
+		existing []client.Object
+		wantName string
+		wantErr  bool
+	}{
+		{
+			desc:    "no elasticsearch",
+			wantErr: true,
+		},
+		{
+			desc:     "single elasticsearch",
+			existing: []client.Object{newCR("elasticsearch", "openshift-logging"), newCR("other", "other")},
+			wantName: "elasticsearch",
+		},
+		{
+			desc:     "several elasticsearch",
+			existing: []client.Object{newCR("elasticsearch-app", "openshift-logging"), newCR("elasticsearch-infra", "openshift-logging")},
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(test.existing...).Build()
+
+			es, err := GetElasticsearchCR(c, "openshift-logging")
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got elasticsearch %q", es.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if es.Name != test.wantName {
+				t.Errorf("got elasticsearch %q, want %q", es.Name, test.wantName)
+			}
+		})
+	}
+}
+
+func TestGetReferencedElasticsearchCR(t *testing.T) {
+	utilruntime.Must(loggingv1.AddToScheme(scheme.Scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			&loggingv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Name: "elasticsearch", Namespace: "openshift-logging"}},
+			&loggingv1.Elasticsearch{
+				ObjectMeta: metav1.ObjectMeta{Name: "elasticsearch", Namespace: "infra"},
+				Spec:       loggingv1.ElasticsearchSpec{AllowedKibanaNamespaces: []string{"openshift-logging"}},
+			},
+		).
+		Build()
+
+	tests := []struct {
+		desc      string
+		ref       loggingv1.ElasticsearchReference
+		namespace string
+		wantErr   bool
+	}{
+		{
+			desc:      "same namespace",
+			ref:       loggingv1.ElasticsearchReference{Name: "elasticsearch"},
+			namespace: "openshift-logging",
+		},
+		{
+			desc:      "namespace allowed by the cluster",
+			ref:       loggingv1.ElasticsearchReference{Name: "elasticsearch", Namespace: "infra"},
+			namespace: "openshift-logging",
+		},
+		{
+			desc:      "namespace not allowed by the cluster",
+			ref:       loggingv1.ElasticsearchReference{Name: "elasticsearch", Namespace: "openshift-logging"},
+			namespace: "tenant",
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := GetReferencedElasticsearchCR(c, &test.ref, test.namespace)
+			if test.wantErr && err == nil {
+				t.Error("expected an error")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -16,11 +16,15 @@ const (
 	defaultESCpuRequest    = "100m"
 	defaultESMemoryLimit   = "4Gi"
 	defaultESMemoryRequest = "1Gi"
+	defaultHeapPercent     = 50
+	minHeapSize            = 256 * mebibyte
 	// ESProxy
 	defaultESProxyCPURequest    = "100m"
 	defaultESProxyMemoryLimit   = "256Mi"
 	defaultESProxyMemoryRequest = "256Mi"
 
+	mebibyte = 1024 * 1024
+
 	maxMasterCount       = 3
 	maxPrimaryShardCount = 5
 
@@ -44,6 +44,9 @@ func (node *deploymentNode) populateReference(nodeName string, n api.Elasticsear
 	progressDeadlineSeconds := int32(1800)
 	logConfig := getLogConfig(cluster.GetAnnotations())
 	template := newPodTemplateSpec(context.TODO(), node.log, nodeName, cluster.Name, cluster.Namespace, n, cluster.Spec.Spec, labels, roleMap, client, logConfig)
+	if hasTransportCABundle(cluster) {
+		mountTransportCABundle(&template)
+	}
 
 	dpl := deployment.New(nodeName, cluster.Namespace, labels, replicas).
 		WithSelector(metav1.LabelSelector{
@@ -208,6 +211,10 @@ func (node *deploymentNode) checkPodSpecMatches(labels map[string]string) bool {
 			return false
 		}
 
+		if p.Annotations[extraVolumesHashAnnotation] != node.self.Spec.Template.Annotations[extraVolumesHashAnnotation] {
+			return false
+		}
+
 		if !containsContainersReadyCondition(p.Status.Conditions) {
 			return false
 		}
@@ -314,7 +321,7 @@ func (node *deploymentNode) isMissing() bool {
 
 func (node *deploymentNode) executeUpdate() error {
 	equalFunc := func(current, desired *apps.Deployment) bool {
-		return pod.ArePodTemplateSpecEqual(current.Spec.Template, desired.Spec.Template)
+		return arePodTemplateSpecEqual(current.Spec.Template, desired.Spec.Template)
 	}
 
 	mutateFunc := func(current, desired *apps.Deployment) {
@@ -363,6 +370,31 @@ func (node *deploymentNode) progressNodeChanges() error {
 	return nil
 }
 
+// progressProxyChanges updates the paused deployment when only the proxy image changed,
+// so the node is not scheduled for a restart of Elasticsearch. The new image is set on
+// the running pods, other proxy changes are left to the rolling restart of the cluster.
+func (node *deploymentNode) progressProxyChanges() error {
+	key := client.ObjectKey{Name: node.self.Name, Namespace: node.self.Namespace}
+	current, err := deployment.Get(context.TODO(), node.client, key)
+	if err != nil {
+		return err
+	}
+
+	if !isProxyImageOnlyChange(current.Spec.Template, node.self.Spec.Template) {
+		return nil
+	}
+
+	node.log.Info("Rolling out proxy only changes")
+
+	if err := node.executeUpdate(); err != nil {
+		return err
+	}
+
+	return updateProxyContainerImage(context.TODO(), node.client, node.self.Namespace, map[string]string{
+		"node-name": node.name(),
+	}, getESProxyImage())
+}
+
 func (node *deploymentNode) refreshHashes() {
 	key := client.ObjectKey{Name: node.clusterName, Namespace: node.self.Namespace}
 
@@ -385,7 +417,7 @@ func (node *deploymentNode) isChanged() bool {
 		return false
 	}
 
-	return !pod.ArePodTemplateSpecEqual(current.Spec.Template, node.self.Spec.Template)
+	return !arePodTemplateSpecEqual(current.Spec.Template, node.self.Spec.Template)
 }
 
 func containsContainersReadyCondition(conditions []v1.PodCondition) bool {
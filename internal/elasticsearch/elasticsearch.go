@@ -1,11 +1,30 @@
 package elasticsearch
 
 import (
+	"fmt"
+	"reflect"
+	"time"
+
 	"github.com/ViaQ/logerr/v2/kverrors"
 	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
 	estypes "github.com/openshift/elasticsearch-operator/internal/types/elasticsearch"
+	"github.com/openshift/elasticsearch-operator/internal/utils/settings"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// clusterSettingsPolicy protects the persistent cluster settings the operator
+// manages itself and which can not be set from spec.clusterSettings
+var clusterSettingsPolicy = settings.Policy{
+	Kind: "cluster setting",
+	Protected: []string{
+		"cluster.remote",
+		"cluster.routing.allocation.enable",
+		"discovery.zen.minimum_master_nodes",
+		"logger",
+	},
+}
+
 // this function should be called before we try doing operations to make sure all our nodes are
 // first ready
 func (er *ElasticsearchRequest) ClusterReady() bool {
@@ -46,6 +65,11 @@ func (er *ElasticsearchRequest) updateMinMasters() {
 		return
 	}
 
+	// minimum_master_nodes is only used by Zen discovery
+	if !usesZenDiscovery(getESImage()) {
+		return
+	}
+
 	currentMasterCount, err := er.esClient.GetMinMasterNodes()
 	if err != nil {
 		er.L().Info("Unable to get current min master count")
@@ -67,6 +91,34 @@ func (er *ElasticsearchRequest) updateMinMasters() {
 	}
 }
 
+// clearVotingConfigExclusions removes the voting config exclusions once
+// all excluded master nodes have left the cluster
+func (er *ElasticsearchRequest) clearVotingConfigExclusions() {
+	if !er.AnyNodeReady() || usesZenDiscovery(getESImage()) {
+		return
+	}
+
+	excludedNodes, err := er.esClient.GetVotingConfigExclusions()
+	if err != nil {
+		er.L().Error(err, "Unable to get voting config exclusions")
+		return
+	}
+
+	if len(excludedNodes) == 0 {
+		return
+	}
+
+	for _, nodeName := range excludedNodes {
+		if inCluster, err := er.esClient.IsNodeInCluster(nodeName); err != nil || inCluster {
+			return
+		}
+	}
+
+	if err := er.esClient.ClearVotingConfigExclusions(); err != nil {
+		er.L().Error(err, "Unable to clear voting config exclusions")
+	}
+}
+
 func (er *ElasticsearchRequest) tryEnsureAllShardAllocation() {
 	if !er.AnyNodeReady() {
 		return
@@ -103,6 +155,287 @@ func (er *ElasticsearchRequest) updatePrimaryShards() {
 	}
 }
 
+// updateClusterSettings applies spec.clusterSettings as persistent cluster settings,
+// reverts any drift and resets settings that were removed from the spec.
+func (er *ElasticsearchRequest) updateClusterSettings() {
+	cluster := er.cluster
+	desired := cluster.Spec.ClusterSettings
+	applied := cluster.Status.ClusterSettings
+
+	if len(desired) == 0 && len(applied) == 0 {
+		return
+	}
+
+	if !er.AnyNodeReady() {
+		return
+	}
+
+	if err := validateClusterSettings(desired); err != nil {
+		if err := updateInvalidClusterSettingsCondition(cluster, v1.ConditionTrue, "Invalid Settings", err.Error(), er.client); err != nil {
+			er.L().Error(err, "Unable to set cluster settings status")
+		}
+		return
+	}
+
+	current, err := er.esClient.GetPersistentClusterSettings()
+	if err != nil {
+		er.L().Error(err, "Unable to get persistent cluster settings")
+		return
+	}
+
+	if changes := clusterSettingsChanges(desired, applied, current); len(changes) > 0 {
+		if err := er.esClient.UpdatePersistentClusterSettings(changes); err != nil {
+			er.L().Error(err, "Unable to update persistent cluster settings")
+			if err := updateInvalidClusterSettingsCondition(cluster, v1.ConditionTrue, "Update Failed", err.Error(), er.client); err != nil {
+				er.L().Error(err, "Unable to set cluster settings status")
+			}
+			return
+		}
+	}
+
+	if err := updateInvalidClusterSettingsCondition(cluster, v1.ConditionFalse, "", "", er.client); err != nil {
+		er.L().Error(err, "Unable to set cluster settings status")
+	}
+	if err := updateAppliedClusterSettings(cluster, desired, er.client); err != nil {
+		er.L().Error(err, "Unable to set cluster settings status")
+	}
+}
+
+// clusterSettingsChanges returns the persistent settings that need to be sent to the cluster
+// so that it matches the desired settings. Settings which were applied before but are no
+// longer desired are reset to their defaults.
+func clusterSettingsChanges(desired, applied map[string]string, current map[string]interface{}) map[string]interface{} {
+	changes := map[string]interface{}{}
+
+	for key, value := range desired {
+		if currentValue, ok := current[key]; !ok || fmt.Sprint(currentValue) != value {
+			changes[key] = value
+		}
+	}
+
+	for key := range applied {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		if _, ok := current[key]; ok {
+			changes[key] = nil
+		}
+	}
+
+	return changes
+}
+
+// validateClusterSettings ensures that the settings contain only well-formed keys not owned by the operator
+func validateClusterSettings(clusterSettings map[string]string) error {
+	return clusterSettingsPolicy.Validate(clusterSettings)
+}
+
+// updateLoggers applies the logger levels from spec.logging as persistent cluster settings.
+// Debug and trace levels are reset once their TTL expired and loggers removed from the spec are reset.
+func (er *ElasticsearchRequest) updateLoggers() {
+	cluster := er.cluster
+	applied := cluster.Status.Logging
+
+	var spec *api.ElasticsearchLoggingSpec
+	if cluster.Spec.Logging != nil && len(cluster.Spec.Logging.Loggers) > 0 {
+		spec = cluster.Spec.Logging
+	}
+
+	if spec == nil && (applied == nil || len(applied.Loggers) == 0) {
+		return
+	}
+
+	if !er.AnyNodeReady() {
+		return
+	}
+
+	now := time.Now()
+	desired := newLoggingStatus(spec, applied, now)
+
+	if err := validateLoggers(desired); err != nil {
+		er.L().Error(err, "Unable to apply logger levels")
+		return
+	}
+
+	current, err := er.esClient.GetPersistentClusterSettings()
+	if err != nil {
+		er.L().Error(err, "Unable to get persistent cluster settings")
+		return
+	}
+
+	var appliedLoggers map[string]string
+	if applied != nil {
+		appliedLoggers = loggerSettings(applied.Loggers)
+	}
+
+	changes := clusterSettingsChanges(loggerSettings(activeLoggers(desired, now)), appliedLoggers, current)
+	if len(changes) > 0 {
+		if err := er.esClient.UpdatePersistentClusterSettings(changes); err != nil {
+			er.L().Error(err, "Unable to update logger levels")
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(desired, applied) {
+		if err := updateLoggingStatus(cluster, desired, er.client); err != nil {
+			er.L().Error(err, "Unable to set logging status")
+		}
+	}
+}
+
+// newLoggingStatus returns the loggers to apply. The TTL of debug and trace levels
+// starts over whenever the loggers change.
+func newLoggingStatus(spec *api.ElasticsearchLoggingSpec, applied *api.ElasticsearchLoggingStatus, now time.Time) *api.ElasticsearchLoggingStatus {
+	if spec == nil || len(spec.Loggers) == 0 {
+		return nil
+	}
+
+	status := &api.ElasticsearchLoggingStatus{
+		Loggers: map[string]api.LogLevel{},
+	}
+	for name, level := range spec.Loggers {
+		status.Loggers[name] = level
+	}
+
+	if spec.TTL == nil || !hasVerboseLoggers(spec.Loggers) {
+		return status
+	}
+
+	if applied != nil && applied.ExpiresAt != nil && reflect.DeepEqual(applied.Loggers, spec.Loggers) {
+		status.ExpiresAt = applied.ExpiresAt.DeepCopy()
+		return status
+	}
+
+	expiresAt := metav1.NewTime(now.Add(spec.TTL.Duration))
+	status.ExpiresAt = &expiresAt
+
+	return status
+}
+
+// activeLoggers returns the logger levels in effect, without debug and trace levels once expired
+func activeLoggers(status *api.ElasticsearchLoggingStatus, now time.Time) map[string]api.LogLevel {
+	if status == nil {
+		return nil
+	}
+
+	expired := status.ExpiresAt != nil && !now.Before(status.ExpiresAt.Time)
+
+	loggers := map[string]api.LogLevel{}
+	for name, level := range status.Loggers {
+		if expired && isVerboseLogLevel(level) {
+			continue
+		}
+		loggers[name] = level
+	}
+
+	return loggers
+}
+
+func hasVerboseLoggers(loggers map[string]api.LogLevel) bool {
+	for _, level := range loggers {
+		if isVerboseLogLevel(level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isVerboseLogLevel(level api.LogLevel) bool {
+	return level == api.LogLevelDebug || level == api.LogLevelTrace
+}
+
+// loggerSettings returns the logger levels as logger.* cluster settings
+func loggerSettings(loggers map[string]api.LogLevel) map[string]string {
+	settings := map[string]string{}
+	for name, level := range loggers {
+		settings["logger."+name] = string(level)
+	}
+
+	return settings
+}
+
+// validateLoggers ensures that the logger names are well-formed
+func validateLoggers(status *api.ElasticsearchLoggingStatus) error {
+	if status == nil {
+		return nil
+	}
+
+	for name := range status.Loggers {
+		if !settings.KeyRegexp.MatchString(name) {
+			return kverrors.New("invalid logger name", "logger", name)
+		}
+	}
+
+	return nil
+}
+
+// updateSlowLog applies the slow log thresholds to the managed index templates and live indices
+func (er *ElasticsearchRequest) updateSlowLog() {
+	if !er.ClusterReady() {
+		return
+	}
+
+	search, indexing := newSlowlogSettings(er.cluster.Spec.SlowLog)
+	if err := er.esClient.UpdateSlowlogThresholds(search, indexing); err != nil {
+		er.L().Error(err, "Unable to update slow log thresholds")
+	}
+}
+
+// newSlowlogSettings returns the search and indexing slow log settings, which are nil
+// when no threshold is defined for them
+func newSlowlogSettings(spec *api.ElasticsearchSlowLogSpec) (*estypes.SlowlogIndexSetting, *estypes.SlowlogIndexSetting) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	var search, indexing *estypes.SlowlogIndexSetting
+
+	query := newSlowlogLevelSetting(spec.SearchQuery)
+	fetch := newSlowlogLevelSetting(spec.SearchFetch)
+	if query != nil || fetch != nil {
+		search = &estypes.SlowlogIndexSetting{
+			Slowlog: estypes.SlowlogSetting{
+				Threshold: estypes.SlowlogThresholdSetting{
+					Query: query,
+					Fetch: fetch,
+				},
+			},
+		}
+	}
+
+	if index := newSlowlogLevelSetting(spec.Indexing); index != nil {
+		indexing = &estypes.SlowlogIndexSetting{
+			Slowlog: estypes.SlowlogSetting{
+				Threshold: estypes.SlowlogThresholdSetting{
+					Index: index,
+				},
+			},
+		}
+	}
+
+	return search, indexing
+}
+
+func newSlowlogLevelSetting(thresholds *api.SlowLogThresholds) *estypes.SlowlogLevelSetting {
+	if thresholds == nil {
+		return nil
+	}
+
+	levels := &estypes.SlowlogLevelSetting{
+		Warn:  string(thresholds.Warn),
+		Info:  string(thresholds.Info),
+		Debug: string(thresholds.Debug),
+		Trace: string(thresholds.Trace),
+	}
+
+	if *levels == (estypes.SlowlogLevelSetting{}) {
+		return nil
+	}
+
+	return levels
+}
+
 func (er *ElasticsearchRequest) isIndexBlocked(index string) bool {
 	currSetting, err := er.esClient.GetIndexSettings(index)
 	if err != nil {
@@ -2,11 +2,15 @@ package elasticsearch
 
 import (
 	"net/http"
+	"reflect"
 	"testing"
+	"time"
 
+	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
 	"github.com/openshift/elasticsearch-operator/internal/constants"
 	"github.com/openshift/elasticsearch-operator/internal/elasticsearch/esclient"
 	"github.com/openshift/elasticsearch-operator/test/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -140,3 +144,144 @@ func TestCreateSettingToUnblock(t *testing.T) {
 				}
 			}`)
 }
+
+func TestClusterSettingsChanges(t *testing.T) {
+	tests := []struct {
+		desc    string
+		desired map[string]string
+		applied map[string]string
+		current map[string]interface{}
+		want    map[string]interface{}
+	}{
+		{
+			desc:    "in sync",
+			desired: map[string]string{"search.max_buckets": "1000"},
+			applied: map[string]string{"search.max_buckets": "1000"},
+			current: map[string]interface{}{"search.max_buckets": "1000"},
+			want:    map[string]interface{}{},
+		},
+		{
+			desc:    "missing setting",
+			desired: map[string]string{"search.max_buckets": "1000"},
+			current: map[string]interface{}{},
+			want:    map[string]interface{}{"search.max_buckets": "1000"},
+		},
+		{
+			desc:    "drifted setting",
+			desired: map[string]string{"search.max_buckets": "1000"},
+			applied: map[string]string{"search.max_buckets": "1000"},
+			current: map[string]interface{}{"search.max_buckets": "5000"},
+			want:    map[string]interface{}{"search.max_buckets": "1000"},
+		},
+		{
+			desc:    "removed setting",
+			applied: map[string]string{"search.max_buckets": "1000"},
+			current: map[string]interface{}{"search.max_buckets": "1000", "cluster.routing.allocation.enable": "all"},
+			want:    map[string]interface{}{"search.max_buckets": nil},
+		},
+		{
+			desc:    "removed setting already reset",
+			applied: map[string]string{"search.max_buckets": "1000"},
+			current: map[string]interface{}{},
+			want:    map[string]interface{}{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			got := clusterSettingsChanges(test.desired, test.applied, test.current)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateClusterSettings(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings map[string]string
+		wantErr  bool
+	}{
+		{
+			desc:     "dynamic settings",
+			settings: map[string]string{"search.max_buckets": "1000", "indices.recovery.max_bytes_per_sec": "100mb"},
+		},
+		{
+			desc:     "malformed setting name",
+			settings: map[string]string{"search..max_buckets": "1000"},
+			wantErr:  true,
+		},
+		{
+			desc:     "operator managed setting",
+			settings: map[string]string{"cluster.routing.allocation.enable": "none"},
+			wantErr:  true,
+		},
+		{
+			desc:     "logger managed by the logging spec",
+			settings: map[string]string{"logger.org.elasticsearch.discovery": "debug"},
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			err := validateClusterSettings(test.settings)
+			if (err != nil) != test.wantErr {
+				t.Errorf("got err %v, wantErr %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoggerLevelsRevertAfterTTL(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	spec := &api.ElasticsearchLoggingSpec{
+		Loggers: map[string]api.LogLevel{
+			"org.elasticsearch.discovery": api.LogLevelDebug,
+			"org.elasticsearch.cluster":   api.LogLevelWarn,
+		},
+		TTL: &metav1.Duration{Duration: time.Hour},
+	}
+
+	status := newLoggingStatus(spec, nil, now)
+	if status.ExpiresAt == nil || !status.ExpiresAt.Time.Equal(now.Add(time.Hour)) {
+		t.Fatalf("got expiry %v, want %v", status.ExpiresAt, now.Add(time.Hour))
+	}
+
+	later := now.Add(30 * time.Minute)
+	if got := newLoggingStatus(spec, status, later); !reflect.DeepEqual(got, status) {
+		t.Errorf("Exp. the expiry to be kept for unchanged loggers, got %v", got.ExpiresAt)
+	}
+
+	if got := activeLoggers(status, later); !reflect.DeepEqual(got, spec.Loggers) {
+		t.Errorf("got %v, want %v", got, spec.Loggers)
+	}
+
+	want := map[string]api.LogLevel{"org.elasticsearch.cluster": api.LogLevelWarn}
+	if got := activeLoggers(status, now.Add(2*time.Hour)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	changed := spec.DeepCopy()
+	changed.Loggers["org.elasticsearch.transport"] = api.LogLevelTrace
+	if got := newLoggingStatus(changed, status, later); !got.ExpiresAt.Time.Equal(later.Add(time.Hour)) {
+		t.Errorf("Exp. the expiry to start over for changed loggers, got %v", got.ExpiresAt)
+	}
+}
+
+func TestLoggerSettings(t *testing.T) {
+	loggers := map[string]api.LogLevel{"org.elasticsearch.discovery": api.LogLevelDebug}
+	applied := loggerSettings(map[string]api.LogLevel{"org.elasticsearch.transport": api.LogLevelTrace})
+	current := map[string]interface{}{"logger.org.elasticsearch.transport": "trace"}
+
+	want := map[string]interface{}{
+		"logger.org.elasticsearch.discovery": "debug",
+		"logger.org.elasticsearch.transport": nil,
+	}
+	if got := clusterSettingsChanges(loggerSettings(loggers), applied, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
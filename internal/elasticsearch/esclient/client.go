@@ -33,6 +33,7 @@ const (
 
 type Client interface {
 	ClusterName() string
+	ClusterNamespace() string
 
 	// Cluster Settings API
 	GetClusterNodeVersions() ([]string, error)
@@ -40,7 +41,13 @@ type Client interface {
 	GetDiskWatermarks() (interface{}, interface{}, interface{}, error)
 	GetMinMasterNodes() (int32, error)
 	SetMinMasterNodes(numberMasters int32) (bool, error)
+	GetPersistentClusterSettings() (map[string]interface{}, error)
+	UpdatePersistentClusterSettings(settings map[string]interface{}) error
+	GetRemoteClusterInfo() (estypes.RemoteInfoResponse, error)
 	DoSynchronizedFlush() (bool, error)
+	AddVotingConfigExclusions(nodeNames []string) error
+	GetVotingConfigExclusions() ([]string, error)
+	ClearVotingConfigExclusions() error
 
 	// Cluster State API
 	GetLowestClusterVersion() (string, error)
@@ -56,11 +63,14 @@ type Client interface {
 	CreateIndex(name string, index *estypes.Index) error
 	ReIndex(src, dst, script, lang string) error
 	GetAllIndices(name string) (estypes.CatIndicesResponses, error)
+	ListIndicesByCreationDate(name string) ([]string, error)
+	DeleteIndex(name string) error
 
 	// Index Alias API
 	ListIndicesForAlias(aliasPattern string) ([]string, error)
 	UpdateAlias(actions estypes.AliasActions) error
 	AddAliasForOldIndices() bool
+	ListWriteIndices(name string) ([]string, error)
 
 	// Index Settings API
 	GetIndexSettings(name string) (*estypes.Index, error)
@@ -85,6 +95,7 @@ type Client interface {
 	ListTemplates() (sets.String, error)
 	GetIndexTemplates() (map[string]estypes.GetIndexTemplate, error)
 	UpdateTemplatePrimaryShards(shardCount int32) error
+	UpdateSlowlogThresholds(search, indexing *estypes.SlowlogIndexSetting) error
 
 	SetSendRequestFn(fn FnEsSendRequest)
 }
@@ -127,6 +138,10 @@ func (ec *esClient) ClusterName() string {
 	return ec.cluster
 }
 
+func (ec *esClient) ClusterNamespace() string {
+	return ec.namespace
+}
+
 func (ec *esClient) errorCtx() kverrors.Context {
 	return kverrors.NewContext(
 		"namespace", ec.namespace,
@@ -173,6 +188,8 @@ func sendEsRequest(log logr.Logger, cluster, namespace string, payload *EsReques
 			request.Body = ioutil.NopCloser(bytes.NewReader([]byte(payload.RequestBody)))
 		}
 
+	case http.MethodDelete:
+		// no more to do to request...
 	default:
 		// unsupported method -- do nothing
 		return
@@ -257,6 +274,8 @@ func sendRequestWithMTlsClient(log logr.Logger, clusterName, namespace string, p
 			request.Body = ioutil.NopCloser(bytes.NewReader([]byte(payload.RequestBody)))
 		}
 
+	case http.MethodDelete:
+		// no more to do to request...
 	default:
 		// unsupported method -- do nothing
 		return
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ViaQ/logerr/v2/kverrors"
 	estypes "github.com/openshift/elasticsearch-operator/internal/types/elasticsearch"
+	"github.com/openshift/elasticsearch-operator/internal/utils"
 	"github.com/openshift/elasticsearch-operator/internal/utils/comparators"
 )
 
@@ -185,6 +186,89 @@ func (ec *esClient) SetMinMasterNodes(numberMasters int32) (bool, error) {
 	return payload.StatusCode == 200 && acknowledged, payload.Error
 }
 
+// GetPersistentClusterSettings returns the persistent cluster settings keyed by their flat name
+func (ec *esClient) GetPersistentClusterSettings() (map[string]interface{}, error) {
+	payload := &EsRequest{
+		Method: http.MethodGet,
+		URI:    "_cluster/settings?flat_settings=true",
+	}
+
+	ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
+	if payload.Error != nil {
+		return nil, payload.Error
+	}
+	if payload.StatusCode != http.StatusOK {
+		return nil, ec.errorCtx().New("failed to get cluster settings",
+			"response_status", payload.StatusCode,
+			"response_body", payload.ResponseBody)
+	}
+
+	settings := map[string]interface{}{}
+	if persistent, ok := payload.ResponseBody["persistent"].(map[string]interface{}); ok {
+		settings = persistent
+	}
+
+	return settings, nil
+}
+
+// UpdatePersistentClusterSettings applies the given persistent cluster settings.
+// A nil value resets the setting to its default.
+func (ec *esClient) UpdatePersistentClusterSettings(settings map[string]interface{}) error {
+	body, err := utils.ToJSON(map[string]interface{}{"persistent": settings})
+	if err != nil {
+		return ec.errorCtx().Wrap(err, "failed to marshal cluster settings")
+	}
+
+	payload := &EsRequest{
+		Method:      http.MethodPut,
+		URI:         "_cluster/settings",
+		RequestBody: body,
+	}
+
+	ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
+	if payload.Error != nil {
+		return payload.Error
+	}
+
+	acknowledged := false
+	if acknowledgedBool, ok := payload.ResponseBody["acknowledged"].(bool); ok {
+		acknowledged = acknowledgedBool
+	}
+
+	if payload.StatusCode != http.StatusOK || !acknowledged {
+		return ec.errorCtx().New("failed to update cluster settings",
+			"response_status", payload.StatusCode,
+			"response_body", payload.ResponseBody)
+	}
+
+	return nil
+}
+
+// GetRemoteClusterInfo returns the connectivity of the remote clusters by alias
+func (ec *esClient) GetRemoteClusterInfo() (estypes.RemoteInfoResponse, error) {
+	payload := &EsRequest{
+		Method: http.MethodGet,
+		URI:    "_remote/info",
+	}
+
+	ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
+	if payload.Error != nil {
+		return nil, payload.Error
+	}
+	if payload.StatusCode != http.StatusOK {
+		return nil, ec.errorCtx().New("failed to get remote cluster info",
+			"response_status", payload.StatusCode,
+			"response_body", payload.ResponseBody)
+	}
+
+	res := estypes.RemoteInfoResponse{}
+	if err := json.Unmarshal([]byte(payload.RawResponseBody), &res); err != nil {
+		return nil, ec.errorCtx().Wrap(err, "failed to decode raw response body into `estypes.RemoteInfoResponse`")
+	}
+
+	return res, nil
+}
+
 func (ec *esClient) GetMinMasterNodes() (int32, error) {
 	payload := &EsRequest{
 		Method: http.MethodGet,
@@ -204,6 +288,8 @@ func (ec *esClient) GetMinMasterNodes() (int32, error) {
 	return masterCount, payload.Error
 }
 
+// DoSynchronizedFlush flushes all indices using a synced flush and falls back
+// to a plain flush for clusters without synced flush support (8.x and OpenSearch 2.x)
 // TODO: also check that the number of shards in the response > 0?
 func (ec *esClient) DoSynchronizedFlush() (bool, error) {
 	payload := &EsRequest{
@@ -213,6 +299,15 @@ func (ec *esClient) DoSynchronizedFlush() (bool, error) {
 
 	ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
 
+	if payload.Error == nil && isUnsupportedEndpoint(payload.StatusCode) {
+		payload = &EsRequest{
+			Method: http.MethodPost,
+			URI:    "_flush",
+		}
+
+		ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
+	}
+
 	failed := 0
 	if shards, ok := payload.ResponseBody["_shards"].(map[string]interface{}); ok {
 		if failedFload, ok := shards["failed"].(float64); ok {
@@ -228,6 +323,86 @@ func (ec *esClient) DoSynchronizedFlush() (bool, error) {
 	return payload.StatusCode == 200, payload.Error
 }
 
+func isUnsupportedEndpoint(statusCode int) bool {
+	return statusCode == http.StatusBadRequest ||
+		statusCode == http.StatusNotFound ||
+		statusCode == http.StatusMethodNotAllowed
+}
+
+// AddVotingConfigExclusions excludes master eligible nodes from the voting configuration
+// before they are removed from clusters without Zen discovery
+func (ec *esClient) AddVotingConfigExclusions(nodeNames []string) error {
+	payload := &EsRequest{
+		Method: http.MethodPost,
+		URI:    fmt.Sprintf("_cluster/voting_config_exclusions?node_names=%s", strings.Join(nodeNames, ",")),
+	}
+
+	ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
+	if payload.Error != nil {
+		return payload.Error
+	}
+	if payload.StatusCode != http.StatusOK {
+		return ec.errorCtx().New("failed to add voting config exclusions",
+			"node_names", nodeNames,
+			"response_status", payload.StatusCode,
+			"response_body", payload.ResponseBody)
+	}
+
+	return nil
+}
+
+// GetVotingConfigExclusions returns the names of the nodes excluded from the voting configuration
+func (ec *esClient) GetVotingConfigExclusions() ([]string, error) {
+	payload := &EsRequest{
+		Method: http.MethodGet,
+		URI:    "_cluster/state/metadata?filter_path=metadata.cluster_coordination.voting_config_exclusions",
+	}
+
+	ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
+	if payload.Error != nil {
+		return nil, payload.Error
+	}
+	if payload.StatusCode != http.StatusOK {
+		return nil, ec.errorCtx().New("failed to get voting config exclusions",
+			"response_status", payload.StatusCode,
+			"response_body", payload.ResponseBody)
+	}
+
+	nodeNames := []string{}
+	if exclusions, ok := walkInterfaceMap("metadata.cluster_coordination.voting_config_exclusions", payload.ResponseBody).([]interface{}); ok {
+		for _, exclusion := range exclusions {
+			if exclusionMap, ok := exclusion.(map[string]interface{}); ok {
+				if nodeName, ok := exclusionMap["node_name"].(string); ok {
+					nodeNames = append(nodeNames, nodeName)
+				}
+			}
+		}
+	}
+
+	return nodeNames, nil
+}
+
+// ClearVotingConfigExclusions removes all voting config exclusions without
+// waiting for the excluded nodes to leave the cluster
+func (ec *esClient) ClearVotingConfigExclusions() error {
+	payload := &EsRequest{
+		Method: http.MethodDelete,
+		URI:    "_cluster/voting_config_exclusions?wait_for_removal=false",
+	}
+
+	ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
+	if payload.Error != nil {
+		return payload.Error
+	}
+	if payload.StatusCode != http.StatusOK {
+		return ec.errorCtx().New("failed to clear voting config exclusions",
+			"response_status", payload.StatusCode,
+			"response_body", payload.ResponseBody)
+	}
+
+	return nil
+}
+
 func (ec *esClient) GetLowestClusterVersion() (string, error) {
 	payload := &EsRequest{
 		Method: http.MethodGet,
@@ -135,3 +135,153 @@ func TestIsNodeInCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPersistentClusterSettings(t *testing.T) {
+	chatter := helpers.NewFakeElasticsearchChatter(map[string]helpers.FakeElasticsearchResponses{
+		"_cluster/settings?flat_settings=true": {
+			{
+				StatusCode: 200,
+				Body:       `{"persistent": {}, "transient": {}}`,
+			},
+			{
+				StatusCode: 200,
+				Body:       `{"persistent": {"indices.recovery.max_bytes_per_sec": "100mb"}, "transient": {"search.max_buckets": "1000"}}`,
+			},
+		},
+	})
+	esClient := helpers.NewFakeElasticsearchClient("elasticsearch", "test-namespace", fakeClient, chatter)
+
+	tests := []struct {
+		desc string
+		want map[string]interface{}
+	}{
+		{
+			desc: "no persistent settings",
+			want: map[string]interface{}{},
+		},
+		{
+			desc: "persistent settings only",
+			want: map[string]interface{}{"indices.recovery.max_bytes_per_sec": "100mb"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := esClient.GetPersistentClusterSettings()
+			if err != nil {
+				t.Errorf("got err: %s", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUpdatePersistentClusterSettings(t *testing.T) {
+	chatter := helpers.NewFakeElasticsearchChatter(map[string]helpers.FakeElasticsearchResponses{
+		"_cluster/settings": {
+			{
+				StatusCode: 200,
+				Body:       `{"acknowledged": true}`,
+			},
+			{
+				StatusCode: 400,
+				Body:       `{"error": {"type": "illegal_argument_exception"}}`,
+			},
+		},
+	})
+	esClient := helpers.NewFakeElasticsearchClient("elasticsearch", "test-namespace", fakeClient, chatter)
+
+	settings := map[string]interface{}{
+		"indices.recovery.max_bytes_per_sec": "100mb",
+		"search.max_buckets":                 nil,
+	}
+
+	if err := esClient.UpdatePersistentClusterSettings(settings); err != nil {
+		t.Errorf("got err: %s", err)
+	}
+
+	req, _ := chatter.GetRequest("_cluster/settings")
+	want := `{"persistent":{"indices.recovery.max_bytes_per_sec":"100mb","search.max_buckets":null}}`
+	if req.Body != want {
+		t.Errorf("got body %s, want %s", req.Body, want)
+	}
+
+	if err := esClient.UpdatePersistentClusterSettings(settings); err == nil {
+		t.Error("expected error for a rejected setting")
+	}
+}
+
+func TestDoSynchronizedFlushFallsBackToFlush(t *testing.T) {
+	chatter := helpers.NewFakeElasticsearchChatter(map[string]helpers.FakeElasticsearchResponses{
+		"_flush/synced": {
+			{
+				StatusCode: 400,
+				Body:       `{"error": {"type": "illegal_argument_exception"}}`,
+			},
+		},
+		"_flush": {
+			{
+				StatusCode: 200,
+				Body:       `{"_shards": {"total": 4, "successful": 4, "failed": 0}}`,
+			},
+		},
+	})
+	esClient := helpers.NewFakeElasticsearchClient("elasticsearch", "test-namespace", fakeClient, chatter)
+
+	ok, err := esClient.DoSynchronizedFlush()
+	if err != nil {
+		t.Errorf("got err: %s", err)
+	}
+	if !ok {
+		t.Error("expected flush to succeed")
+	}
+	if _, found := chatter.GetRequest("_flush"); !found {
+		t.Error("expected a plain flush request")
+	}
+}
+
+func TestGetVotingConfigExclusions(t *testing.T) {
+	chatter := helpers.NewFakeElasticsearchChatter(map[string]helpers.FakeElasticsearchResponses{
+		"_cluster/state/metadata?filter_path=metadata.cluster_coordination.voting_config_exclusions": {
+			{
+				StatusCode: 200,
+				Body:       `{}`,
+			},
+			{
+				StatusCode: 200,
+				Body:       `{"metadata": {"cluster_coordination": {"voting_config_exclusions": [{"node_id": "abc", "node_name": "elasticsearch-m-xyz-0"}]}}}`,
+			},
+		},
+	})
+	esClient := helpers.NewFakeElasticsearchClient("elasticsearch", "test-namespace", fakeClient, chatter)
+
+	tests := []struct {
+		desc string
+		want []string
+	}{
+		{
+			desc: "no exclusions",
+			want: []string{},
+		},
+		{
+			desc: "excluded master",
+			want: []string{"elasticsearch-m-xyz-0"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := esClient.GetVotingConfigExclusions()
+			if err != nil {
+				t.Errorf("got err: %s", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
@@ -69,6 +69,58 @@ func (ec *esClient) GetAllIndices(name string) (estypes.CatIndicesResponses, err
 	return res, nil
 }
 
+// ListIndicesByCreationDate returns the indices matching name ordered from the oldest to the newest
+func (ec *esClient) ListIndicesByCreationDate(name string) ([]string, error) {
+	payload := &EsRequest{
+		Method: http.MethodGet,
+		URI:    fmt.Sprintf("_cat/indices/%s?format=json&h=index&s=creation.date", name),
+	}
+	ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
+	if payload.StatusCode == http.StatusNotFound {
+		return []string{}, nil
+	}
+	if payload.Error != nil {
+		return nil, payload.Error
+	}
+	if payload.StatusCode != http.StatusOK {
+		return nil, ec.errorCtx().New("failed to list indices by creation date",
+			"index", name,
+			"response_status", payload.StatusCode,
+			"response_body", payload.ResponseBody)
+	}
+
+	res := estypes.CatIndicesResponses{}
+	raw, _ := payload.ResponseBody["results"].(string)
+	if err := json.Unmarshal([]byte(raw), &res); err != nil {
+		return nil, kverrors.Wrap(err, "failed to parse _cat/indices response body",
+			"index", name)
+	}
+
+	indices := make([]string, 0, len(res))
+	for _, index := range res {
+		indices = append(indices, index.Index)
+	}
+	return indices, nil
+}
+
+func (ec *esClient) DeleteIndex(name string) error {
+	payload := &EsRequest{
+		Method: http.MethodDelete,
+		URI:    name,
+	}
+	ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
+	if payload.Error != nil {
+		return payload.Error
+	}
+	if payload.StatusCode != http.StatusOK && payload.StatusCode != http.StatusNotFound {
+		return ec.errorCtx().New("failed to delete index",
+			"index", name,
+			"response_status", payload.StatusCode,
+			"response_body", payload.ResponseBody)
+	}
+	return nil
+}
+
 func (ec *esClient) CreateIndex(name string, index *estypes.Index) error {
 	body, err := utils.ToJSON(index)
 	if err != nil {
@@ -228,6 +280,63 @@ func (ec *esClient) ListIndicesForAlias(aliasPattern string) ([]string, error) {
 	return response, nil
 }
 
+// ListWriteIndices returns the indices matching name that are the write index of any of their aliases.
+// An alias pointing to a single index without an is_write_index flag writes to that index.
+func (ec *esClient) ListWriteIndices(name string) ([]string, error) {
+	payload := &EsRequest{
+		Method: http.MethodGet,
+		URI:    fmt.Sprintf("%s/_alias", name),
+	}
+
+	ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
+	if payload.StatusCode == http.StatusNotFound {
+		return []string{}, nil
+	}
+	if payload.Error != nil || payload.StatusCode != http.StatusOK {
+		return nil, ec.errorCtx().New("failed to get write indices",
+			"index", name,
+			"response_error", payload.Error,
+			"response_status", payload.StatusCode,
+			"response_body", payload.ResponseBody)
+	}
+
+	indexAliases := map[string]map[string]interface{}{}
+	aliasIndices := map[string]int{}
+	for index, body := range payload.ResponseBody {
+		indexBody, ok := body.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		aliases, ok := indexBody["aliases"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		indexAliases[index] = aliases
+		for alias := range aliases {
+			aliasIndices[alias]++
+		}
+	}
+
+	writeIndices := []string{}
+	for index, aliases := range indexAliases {
+		for alias, body := range aliases {
+			aliasBody, _ := body.(map[string]interface{})
+			if _, flagged := aliasBody["is_write_index"]; flagged {
+				if parseBool("is_write_index", aliasBody) {
+					writeIndices = append(writeIndices, index)
+					break
+				}
+				continue
+			}
+			if aliasIndices[alias] == 1 {
+				writeIndices = append(writeIndices, index)
+				break
+			}
+		}
+	}
+	return writeIndices, nil
+}
+
 func (ec *esClient) AddAliasForOldIndices() bool {
 	// get .operations.*/_alias
 	// get project.*/_alias
@@ -1,8 +1,11 @@
 package esclient_test
 
 import (
+	"sort"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	testhelpers "github.com/openshift/elasticsearch-operator/test/helpers"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -349,3 +352,53 @@ func TestAliasOperationsNotNeeded(t *testing.T) {
 		t.Errorf("Expected creation of aliases to succeed")
 	}
 }
+
+func TestListWriteIndices(t *testing.T) {
+	tests := []struct {
+		desc string
+		body string
+		want []string
+	}{
+		{
+			desc: "write index flag",
+			body: `{"audit-000001": {"aliases": {"audit": {"is_write_index": false}}}, "audit-000002": {"aliases": {"audit": {"is_write_index": true}}}}`,
+			want: []string{"audit-000002"},
+		},
+		{
+			desc: "sole index of an alias without write index flag",
+			body: `{"audit-000001": {"aliases": {"audit": {}}}}`,
+			want: []string{"audit-000001"},
+		},
+		{
+			desc: "several indices of an alias without write index flag",
+			body: `{"audit-000001": {"aliases": {"audit": {}}}, "audit-000002": {"aliases": {"audit": {}}}}`,
+			want: []string{},
+		},
+		{
+			desc: "sole index of an alias flagged as not writable",
+			body: `{"audit-000001": {"aliases": {"audit": {"is_write_index": false}}}}`,
+			want: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			chatter := testhelpers.NewFakeElasticsearchChatter(
+				map[string]testhelpers.FakeElasticsearchResponses{
+					"audit/_alias": {{StatusCode: 200, Body: test.body}},
+				},
+			)
+			esClient := testhelpers.NewFakeElasticsearchClient("elasticsearch", "openshift-logging", fakeClient, chatter)
+
+			got, err := esClient.ListWriteIndices("audit")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			sort.Strings(got)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("write indices mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/openshift/elasticsearch-operator/internal/constants"
 	estypes "github.com/openshift/elasticsearch-operator/internal/types/elasticsearch"
@@ -174,3 +176,130 @@ func (ec *esClient) UpdateTemplatePrimaryShards(shardCount int32) error {
 
 	return nil
 }
+
+// UpdateSlowlogThresholds sets the slow log thresholds of the managed index templates and of the
+// live indices matching them. Only templates with different thresholds and their indices are updated.
+// Thresholds missing on the live indices are disabled.
+func (ec *esClient) UpdateSlowlogThresholds(search, indexing *estypes.SlowlogIndexSetting) error {
+	indexTemplates, err := ec.GetIndexTemplates()
+	if err != nil {
+		return err
+	}
+
+	staleTemplates := map[string]estypes.GetIndexTemplate{}
+	indexPatterns := sets.NewString()
+	for templateName, template := range indexTemplates {
+		if reflect.DeepEqual(template.Settings.Index.Search, search) &&
+			reflect.DeepEqual(template.Settings.Index.Indexing, indexing) {
+			continue
+		}
+
+		staleTemplates[templateName] = template
+		indexPatterns.Insert(template.IndexPatterns...)
+	}
+
+	if len(staleTemplates) == 0 {
+		return nil
+	}
+
+	// update the live indices first so a failure is retried with the templates still stale
+	if indexPatterns.Len() > 0 {
+		settings := &estypes.IndexSettings{
+			Index: &estypes.IndexingSettings{
+				Search: &estypes.SlowlogIndexSetting{
+					Slowlog: estypes.SlowlogSetting{
+						Threshold: estypes.SlowlogThresholdSetting{
+							Query: withDisabledSlowlogLevels(slowlogThreshold(search, "query")),
+							Fetch: withDisabledSlowlogLevels(slowlogThreshold(search, "fetch")),
+						},
+					},
+				},
+				Indexing: &estypes.SlowlogIndexSetting{
+					Slowlog: estypes.SlowlogSetting{
+						Threshold: estypes.SlowlogThresholdSetting{
+							Index: withDisabledSlowlogLevels(slowlogThreshold(indexing, "index")),
+						},
+					},
+				},
+			},
+		}
+
+		if err := ec.UpdateIndexSettings(strings.Join(indexPatterns.List(), ","), settings); err != nil {
+			return err
+		}
+	}
+
+	for templateName, template := range staleTemplates {
+		template.Settings.Index.Search = search
+		template.Settings.Index.Indexing = indexing
+
+		templateJSON, err := json.Marshal(template)
+		if err != nil {
+			return err
+		}
+
+		payload := &EsRequest{
+			Method:      http.MethodPut,
+			URI:         fmt.Sprintf("_template/%s", templateName),
+			RequestBody: string(templateJSON),
+		}
+
+		ec.fnSendEsRequest(ec.log, ec.cluster, ec.namespace, payload, ec.k8sClient)
+
+		acknowledged := false
+		if acknowledgedBool, ok := payload.ResponseBody["acknowledged"].(bool); ok {
+			acknowledged = acknowledgedBool
+		}
+
+		if !(payload.StatusCode == 200 && acknowledged) {
+			return ec.errorCtx().New("failed to update index template slow log thresholds",
+				"template", templateName,
+				"response_status", payload.StatusCode,
+				"response_body", payload.ResponseBody,
+				"response_error", payload.Error)
+		}
+	}
+
+	return nil
+}
+
+func slowlogThreshold(setting *estypes.SlowlogIndexSetting, phase string) *estypes.SlowlogLevelSetting {
+	if setting == nil {
+		return nil
+	}
+
+	switch phase {
+	case "query":
+		return setting.Slowlog.Threshold.Query
+	case "fetch":
+		return setting.Slowlog.Threshold.Fetch
+	case "index":
+		return setting.Slowlog.Threshold.Index
+	}
+
+	return nil
+}
+
+func withDisabledSlowlogLevels(levels *estypes.SlowlogLevelSetting) *estypes.SlowlogLevelSetting {
+	const disabled = "-1"
+
+	result := estypes.SlowlogLevelSetting{Warn: disabled, Info: disabled, Debug: disabled, Trace: disabled}
+	if levels == nil {
+		return &result
+	}
+
+	if levels.Warn != "" {
+		result.Warn = levels.Warn
+	}
+	if levels.Info != "" {
+		result.Info = levels.Info
+	}
+	if levels.Debug != "" {
+		result.Debug = levels.Debug
+	}
+	if levels.Trace != "" {
+		result.Trace = levels.Trace
+	}
+
+	return &result
+}
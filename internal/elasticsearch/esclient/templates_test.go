@@ -88,3 +88,59 @@ func TestCreateIndexTemplateWhenResponse201(t *testing.T) {
 		t.Errorf("Exp. to not return an error %v", err)
 	}
 }
+
+func TestUpdateSlowlogThresholds(t *testing.T) {
+	chatter := testhelpers.NewFakeElasticsearchChatter(
+		map[string]testhelpers.FakeElasticsearchResponses{
+			"_template/common.*,ocp-gen-*": {
+				{
+					StatusCode: http.StatusOK,
+					Body: `{
+						"ocp-gen-app": {"index_patterns": ["app*"], "settings": {"index": {"number_of_shards": "3"}}},
+						"ocp-gen-infra": {"index_patterns": ["infra*"], "settings": {"index": {"number_of_shards": "3", "search": {"slowlog": {"threshold": {"query": {"warn": "10s"}}}}}}}
+					}`,
+				},
+			},
+			"app*/_settings": {
+				{
+					StatusCode: http.StatusOK,
+					Body:       `{"acknowledged": true}`,
+				},
+			},
+			"_template/ocp-gen-app": {
+				{
+					StatusCode: http.StatusOK,
+					Body:       `{"acknowledged": true}`,
+				},
+			},
+		})
+	esClient := testhelpers.NewFakeElasticsearchClient(cluster, namespace, k8sClient, chatter)
+
+	search := &estypes.SlowlogIndexSetting{
+		Slowlog: estypes.SlowlogSetting{
+			Threshold: estypes.SlowlogThresholdSetting{
+				Query: &estypes.SlowlogLevelSetting{Warn: "10s"},
+			},
+		},
+	}
+
+	if err := esClient.UpdateSlowlogThresholds(search, nil); err != nil {
+		t.Errorf("got err: %s", err)
+	}
+
+	req, found := chatter.GetRequest("app*/_settings")
+	if !found {
+		t.Fatal("Exp. the live indices of the stale template to be updated")
+	}
+	want := `{"index":{"search":{"slowlog":{"threshold":{"query":{"warn":"10s","info":"-1","debug":"-1","trace":"-1"},"fetch":{"warn":"-1","info":"-1","debug":"-1","trace":"-1"}}}},"indexing":{"slowlog":{"threshold":{"index":{"warn":"-1","info":"-1","debug":"-1","trace":"-1"}}}}}}`
+	if req.Body != want {
+		t.Errorf("got body %s, want %s", req.Body, want)
+	}
+
+	if _, found := chatter.GetRequest("_template/ocp-gen-app"); !found {
+		t.Error("Exp. the stale template to be updated")
+	}
+	if _, found := chatter.GetRequest("_template/ocp-gen-infra"); found {
+		t.Error("Exp. the up to date template not to be updated")
+	}
+}
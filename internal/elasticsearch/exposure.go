@@ -0,0 +1,170 @@
+package elasticsearch
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/ingress"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/route"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/secret"
+	"github.com/openshift/elasticsearch-operator/internal/utils"
+
+	routev1 "github.com/openshift/api/route/v1"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CreateOrUpdateExposure ensures the Route or the Ingress exposing the REST API of the cluster
+// and removes the one not requested by the spec if it is owned by the cluster
+func (er *ElasticsearchRequest) CreateOrUpdateExposure() error {
+	switch exposureType(er.cluster) {
+	case api.ExposureTypeRoute:
+		if err := er.deleteOwnedExposureIngress(); err != nil {
+			return err
+		}
+		return er.createOrUpdateExposureRoute()
+	case api.ExposureTypeIngress:
+		if err := er.deleteOwnedExposureRoute(); err != nil {
+			return err
+		}
+		return er.createOrUpdateExposureIngress()
+	default:
+		if err := er.deleteOwnedExposureIngress(); err != nil {
+			return err
+		}
+		return er.deleteOwnedExposureRoute()
+	}
+}
+
+func (er *ElasticsearchRequest) createOrUpdateExposureRoute() error {
+	cluster := er.cluster
+
+	key := client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}
+	s, err := secret.Get(context.TODO(), er.client, key)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to get the CA certificate for the elasticsearch route",
+			"cluster", cluster.Name,
+			"namespace", cluster.Namespace,
+		)
+	}
+
+	rt := newExposureRoute(cluster, s.Data[esAdminCAName])
+	cluster.AddOwnerRefTo(rt)
+
+	err = route.CreateOrUpdate(context.TODO(), er.client, rt, route.RouteHostAndTLSConfigEqual, route.MutateHostAndTLSConfig)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to create or update elasticsearch route",
+			"cluster", cluster.Name,
+			"namespace", cluster.Namespace,
+		)
+	}
+
+	return nil
+}
+
+func (er *ElasticsearchRequest) createOrUpdateExposureIngress() error {
+	cluster := er.cluster
+
+	ing := newExposureIngress(cluster)
+	cluster.AddOwnerRefTo(ing)
+
+	err := ingress.CreateOrUpdate(context.TODO(), er.client, ing, ingress.Equal, ingress.Mutate)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to create or update elasticsearch ingress",
+			"cluster", cluster.Name,
+			"namespace", cluster.Namespace,
+		)
+	}
+
+	return nil
+}
+
+// newExposureRoute reencrypts the traffic to the REST API service with the CA that signed
+// the certificate of the cluster as destination CA
+func newExposureRoute(cluster *api.Elasticsearch, caCert []byte) *routev1.Route {
+	exposure := cluster.Spec.Exposure
+
+	return route.New(cluster.Name, cluster.Namespace, cluster.Name, appendDefaultLabel(cluster.Name, map[string]string{})).
+		WithHost(exposure.Host).
+		WithAnnotations(exposure.Annotations).
+		WithTLSConfig(&routev1.TLSConfig{
+			Termination:                   routev1.TLSTerminationReencrypt,
+			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+			DestinationCACertificate:      string(caCert),
+		}).
+		Build()
+}
+
+func newExposureIngress(cluster *api.Elasticsearch) *networking.Ingress {
+	exposure := cluster.Spec.Exposure
+
+	builder := ingress.New(cluster.Name, cluster.Namespace, appendDefaultLabel(cluster.Name, map[string]string{})).
+		WithAnnotations(exposure.Annotations).
+		WithIngressClassName(exposure.IngressClassName).
+		WithServiceBackend(exposure.Host, cluster.Name, 9200)
+
+	if exposure.TLSSecretName != "" {
+		var hosts []string
+		if exposure.Host != "" {
+			hosts = []string{exposure.Host}
+		}
+		builder.WithTLS(exposure.TLSSecretName, hosts...)
+	}
+
+	return builder.Build()
+}
+
+func exposureType(cluster *api.Elasticsearch) api.ElasticsearchExposureType {
+	if cluster.Spec.Exposure == nil {
+		return ""
+	}
+	if cluster.Spec.Exposure.Type == "" {
+		return api.ExposureTypeRoute
+	}
+	return cluster.Spec.Exposure.Type
+}
+
+// deleteOwnedExposureRoute removes the route exposing the cluster. Routes not owned by
+// the cluster, e.g. created by hand, are left alone and clusters without the route API
+// have no route to remove.
+func (er *ElasticsearchRequest) deleteOwnedExposureRoute() error {
+	key := client.ObjectKey{Name: er.cluster.Name, Namespace: er.cluster.Namespace}
+
+	rt, err := route.Get(context.TODO(), er.client, key)
+	if err != nil {
+		if apierrors.IsNotFound(kverrors.Root(err)) || meta.IsNoMatchError(kverrors.Root(err)) {
+			return nil
+		}
+		return err
+	}
+	if !utils.HasOwnerRef(rt, er.cluster.GetOwnerRef()) {
+		return nil
+	}
+
+	return route.Delete(context.TODO(), er.client, key)
+}
+
+// deleteOwnedExposureIngress removes the ingress exposing the cluster. Ingresses not owned
+// by the cluster are left alone.
+func (er *ElasticsearchRequest) deleteOwnedExposureIngress() error {
+	key := client.ObjectKey{Name: er.cluster.Name, Namespace: er.cluster.Namespace}
+
+	ing := &networking.Ingress{}
+	if err := er.client.Get(context.TODO(), key, ing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return kverrors.Wrap(err, "failed to get ingress",
+			"name", key.Name,
+			"namespace", key.Namespace,
+		)
+	}
+	if !utils.HasOwnerRef(ing, er.cluster.GetOwnerRef()) {
+		return nil
+	}
+
+	return ingress.Delete(context.TODO(), er.client, key)
+}
@@ -0,0 +1,168 @@
+package elasticsearch
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestCreateOrUpdateExposure(t *testing.T) {
+	utilruntime.Must(routev1.AddToScheme(scheme.Scheme))
+
+	key := types.NamespacedName{Name: "elasticsearch", Namespace: "openshift-logging"}
+	cluster := &loggingv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "elasticsearch",
+			Namespace: "openshift-logging",
+			UID:       "es-uid",
+		},
+		Spec: loggingv1.ElasticsearchSpec{
+			Exposure: &loggingv1.ElasticsearchExposureSpec{
+				Host: "es.apps.example.com",
+			},
+		},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "elasticsearch",
+			Namespace: "openshift-logging",
+		},
+		Data: map[string][]byte{
+			"admin-ca": []byte("the-ca"),
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(caSecret).Build()
+	er := &ElasticsearchRequest{
+		client:  k8sClient,
+		cluster: cluster,
+		ll:      log.Log,
+	}
+
+	if err := er.CreateOrUpdateExposure(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	rt := &routev1.Route{}
+	if err := k8sClient.Get(context.TODO(), key, rt); err != nil {
+		t.Fatalf("expected the route to be created, got %v", err)
+	}
+	if rt.Spec.Host != "es.apps.example.com" {
+		t.Errorf("expected the route host from the spec, got %q", rt.Spec.Host)
+	}
+	if rt.Spec.TLS.Termination != routev1.TLSTerminationReencrypt || rt.Spec.TLS.DestinationCACertificate != "the-ca" {
+		t.Errorf("expected a reencrypt route with the CA of the cluster, got %v", rt.Spec.TLS)
+	}
+
+	cluster.Spec.Exposure.Type = loggingv1.ExposureTypeIngress
+	cluster.Spec.Exposure.TLSSecretName = "es-ingress-cert"
+	if err := er.CreateOrUpdateExposure(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := k8sClient.Get(context.TODO(), key, &routev1.Route{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the route to be deleted when exposed by an ingress, got %v", err)
+	}
+	ing := &networking.Ingress{}
+	if err := k8sClient.Get(context.TODO(), key, ing); err != nil {
+		t.Fatalf("expected the ingress to be created, got %v", err)
+	}
+	if got := ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Number; got != 9200 {
+		t.Errorf("expected the ingress to target the REST API port, got %d", got)
+	}
+	if got := ing.Spec.TLS[0]; got.SecretName != "es-ingress-cert" || got.Hosts[0] != "es.apps.example.com" {
+		t.Errorf("expected the ingress TLS for the host, got %v", got)
+	}
+
+	cluster.Spec.Exposure = nil
+	if err := er.CreateOrUpdateExposure(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := k8sClient.Get(context.TODO(), key, &networking.Ingress{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the ingress to be deleted without exposure, got %v", err)
+	}
+}
+
+func TestCreateOrUpdateExposureKeepsObjectsNotOwned(t *testing.T) {
+	utilruntime.Must(routev1.AddToScheme(scheme.Scheme))
+
+	key := types.NamespacedName{Name: "elasticsearch", Namespace: "openshift-logging"}
+	cluster := &loggingv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "elasticsearch",
+			Namespace: "openshift-logging",
+			UID:       "es-uid",
+		},
+	}
+	handMade := &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "elasticsearch",
+			Namespace: "openshift-logging",
+		},
+		Spec: routev1.RouteSpec{
+			Host: "es.apps.example.com",
+		},
+	}
+	otherIngress := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "elasticsearch",
+			Namespace:       "openshift-logging",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Elasticsearch", Name: "elasticsearch", UID: "other-uid"}},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(handMade, otherIngress).Build()
+	er := &ElasticsearchRequest{
+		client:  k8sClient,
+		cluster: cluster,
+		ll:      log.Log,
+	}
+
+	if err := er.CreateOrUpdateExposure(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := k8sClient.Get(context.TODO(), key, &routev1.Route{}); err != nil {
+		t.Errorf("expected the route not owned by the cluster to be kept, got %v", err)
+	}
+	if err := k8sClient.Get(context.TODO(), key, &networking.Ingress{}); err != nil {
+		t.Errorf("expected the ingress owned by another cluster to be kept, got %v", err)
+	}
+}
+
+func TestAddDNSNames(t *testing.T) {
+	cr := NewCertificateRequest(log.Log, "elasticsearch", "openshift-logging", metav1.OwnerReference{}, nil)
+	cr.AddDNSNames(esInternalComponentName, "es.apps.example.com", "", "elasticsearch")
+
+	want := []string{"localhost", "elasticsearch", "elasticsearch.openshift-logging.svc", "es.apps.example.com"}
+	got := cr.Extensions[esInternalComponentName].dns
+	if len(got) != len(want) {
+		t.Fatalf("expected SANs %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected SANs %v, got %v", want, got)
+		}
+	}
+
+	issued := &x509.Certificate{DNSNames: want[:3]}
+	if hasDNSNames(issued, got) {
+		t.Errorf("expected a certificate without the exposure host to be regenerated")
+	}
+	issued.DNSNames = want
+	if !hasDNSNames(issued, got) {
+		t.Errorf("expected a certificate with all names to be kept")
+	}
+}
@@ -0,0 +1,167 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/networkpolicy"
+	"github.com/openshift/elasticsearch-operator/internal/utils"
+	v1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const monitoringNamespace = "openshift-monitoring"
+
+// CreateOrUpdateNetworkPolicy ensures the network policy of the cluster pods when enabled
+// in the spec and removes the one owned by the cluster otherwise
+func (er *ElasticsearchRequest) CreateOrUpdateNetworkPolicy() error {
+	cluster := er.cluster
+
+	if cluster.Spec.NetworkPolicy == nil || !cluster.Spec.NetworkPolicy.Enabled {
+		return er.deleteOwnedNetworkPolicy()
+	}
+
+	policy := er.newNetworkPolicy()
+	cluster.AddOwnerRefTo(policy)
+
+	err := networkpolicy.CreateOrUpdate(context.TODO(), er.client, policy, networkpolicy.Equal, networkpolicy.Mutate)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to create or update elasticsearch networkpolicy",
+			"cluster", cluster.Name,
+			"namespace", cluster.Namespace,
+		)
+	}
+
+	return nil
+}
+
+// deleteOwnedNetworkPolicy removes the network policy of the cluster if it exists and is
+// owned by the cluster
+func (er *ElasticsearchRequest) deleteOwnedNetworkPolicy() error {
+	cluster := er.cluster
+	key := client.ObjectKey{Name: networkPolicyName(cluster.Name), Namespace: cluster.Namespace}
+
+	current := &networking.NetworkPolicy{}
+	if err := er.client.Get(context.TODO(), key, current); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return kverrors.Wrap(err, "failed to get elasticsearch networkpolicy",
+			"cluster", cluster.Name,
+			"namespace", cluster.Namespace,
+		)
+	}
+	if !utils.HasOwnerRef(current, cluster.GetOwnerRef()) {
+		return nil
+	}
+
+	return networkpolicy.Delete(context.TODO(), er.client, key)
+}
+
+func networkPolicyName(clusterName string) string {
+	return fmt.Sprintf("%s-network-policy", clusterName)
+}
+
+// newNetworkPolicy admits to the REST API, served by the proxy behind the service port 9200,
+// the operator from any namespace, the cluster's own pods, Kibana, the index management jobs,
+// the router when exposed by a route and the clients of the spec.
+// The transport port stays reserved to the cluster pods and the metrics port to the monitoring namespace.
+func (er *ElasticsearchRequest) newNetworkPolicy() *networking.NetworkPolicy {
+	cluster := er.cluster
+	clusterPods := map[string]string{
+		"cluster-name": cluster.Name,
+		"component":    "elasticsearch",
+	}
+
+	restPeers := []networking.NetworkPolicyPeer{
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"name": "elasticsearch-operator",
+				},
+			},
+			// This needs to be present but empty so it will select all namespaces
+			// since we do not have a label for our operator namespace
+			NamespaceSelector: &metav1.LabelSelector{},
+		},
+		{
+			PodSelector: &metav1.LabelSelector{MatchLabels: clusterPods},
+		},
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"component": "kibana",
+				},
+			},
+		},
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"component":     "indexManagement",
+					"logging-infra": "indexManagement",
+				},
+			},
+		},
+	}
+	if exposureType(cluster) == api.ExposureTypeRoute {
+		restPeers = append(restPeers, networking.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"network.openshift.io/policy-group": "ingress",
+				},
+			},
+		})
+	}
+	for _, c := range cluster.Spec.NetworkPolicy.Clients {
+		restPeers = append(restPeers, networking.NetworkPolicyPeer{
+			NamespaceSelector: c.NamespaceSelector.DeepCopy(),
+			PodSelector:       c.PodSelector.DeepCopy(),
+		})
+	}
+
+	return networkpolicy.New(networkPolicyName(cluster.Name), cluster.Namespace, appendDefaultLabel(cluster.Name, map[string]string{})).
+		WithPodSelector(clusterPods).
+		WithIngressRules(
+			networking.NetworkPolicyIngressRule{
+				From:  restPeers,
+				Ports: networkPolicyPorts(intstr.FromString("restapi"), intstr.FromInt(9200)),
+			},
+			networking.NetworkPolicyIngressRule{
+				From: []networking.NetworkPolicyPeer{
+					{PodSelector: &metav1.LabelSelector{MatchLabels: clusterPods}},
+				},
+				Ports: networkPolicyPorts(intstr.FromInt(9300)),
+			},
+			networking.NetworkPolicyIngressRule{
+				From: []networking.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"kubernetes.io/metadata.name": monitoringNamespace,
+							},
+						},
+					},
+				},
+				Ports: networkPolicyPorts(intstr.FromString("metrics")),
+			},
+		).
+		Build()
+}
+
+func networkPolicyPorts(ports ...intstr.IntOrString) []networking.NetworkPolicyPort {
+	protocol := v1.ProtocolTCP
+	policyPorts := make([]networking.NetworkPolicyPort, 0, len(ports))
+	for i := range ports {
+		policyPorts = append(policyPorts, networking.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &ports[i],
+		})
+	}
+	return policyPorts
+}
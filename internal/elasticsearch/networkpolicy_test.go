@@ -0,0 +1,107 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestCreateOrUpdateNetworkPolicy(t *testing.T) {
+	key := types.NamespacedName{Name: "elasticsearch-network-policy", Namespace: "openshift-logging"}
+	clientSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "collector"}}
+
+	cluster := &loggingv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "elasticsearch",
+			Namespace: "openshift-logging",
+			UID:       "es-uid",
+		},
+		Spec: loggingv1.ElasticsearchSpec{
+			NetworkPolicy: &loggingv1.ElasticsearchNetworkPolicySpec{
+				Enabled: true,
+				Clients: []loggingv1.ElasticsearchNetworkPolicyClient{
+					{PodSelector: clientSelector},
+				},
+			},
+		},
+	}
+
+	k8sClient := fake.NewFakeClient()
+	er := &ElasticsearchRequest{
+		client:  k8sClient,
+		cluster: cluster,
+		ll:      log.Log,
+	}
+
+	if err := er.CreateOrUpdateNetworkPolicy(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	policy := &networking.NetworkPolicy{}
+	if err := k8sClient.Get(context.TODO(), key, policy); err != nil {
+		t.Fatalf("expected the network policy to be created, got %v", err)
+	}
+	if got := policy.Spec.PodSelector.MatchLabels["cluster-name"]; got != "elasticsearch" {
+		t.Errorf("expected the policy to select the cluster pods, got %q", got)
+	}
+	if got := len(policy.Spec.Ingress); got != 3 {
+		t.Fatalf("expected rules for the REST API, the transport and the metrics ports, got %d", got)
+	}
+
+	rest := policy.Spec.Ingress[0]
+	if got := rest.Ports[0].Port.String(); got != "restapi" {
+		t.Errorf("expected the first rule to admit the REST API port of the proxy, got %q", got)
+	}
+	admitsIndexManagement := false
+	for _, peer := range rest.From {
+		labels := peer.PodSelector.MatchLabels
+		if peer.NamespaceSelector == nil && labels["component"] == "indexManagement" && labels["logging-infra"] == "indexManagement" {
+			admitsIndexManagement = true
+		}
+	}
+	if !admitsIndexManagement {
+		t.Errorf("expected the index management jobs to be admitted to the REST API, got %v", rest.From)
+	}
+	client := rest.From[len(rest.From)-1]
+	if client.NamespaceSelector != nil || client.PodSelector.MatchLabels["app"] != "collector" {
+		t.Errorf("expected the client of the spec to be admitted from the cluster namespace, got %v", client)
+	}
+
+	metrics := policy.Spec.Ingress[2]
+	if got := metrics.From[0].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"]; got != "openshift-monitoring" {
+		t.Errorf("expected metrics to be admitted from the monitoring namespace, got %q", got)
+	}
+
+	cluster.Spec.NetworkPolicy.Enabled = false
+	if err := er.CreateOrUpdateNetworkPolicy(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err := k8sClient.Get(context.TODO(), key, &networking.NetworkPolicy{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the network policy to be deleted when disabled, got %v", err)
+	}
+
+	handMade := &networking.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+	}
+	if err := k8sClient.Create(context.TODO(), handMade); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := er.CreateOrUpdateNetworkPolicy(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := k8sClient.Get(context.TODO(), key, &networking.NetworkPolicy{}); err != nil {
+		t.Errorf("expected the network policy not owned by the cluster to be kept, got %v", err)
+	}
+}
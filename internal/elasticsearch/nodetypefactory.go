@@ -26,6 +26,7 @@ type NodeTypeInterface interface {
 	scaleDown() error
 	scaleUp() error
 	progressNodeChanges() error              // this function is used to tell the node to push out its changes
+	progressProxyChanges() error             // this function is used to push out changes limited to the proxy container
 	waitForNodeRejoinCluster() (bool, error) // this function is used to determine if a node has rejoined the cluster
 	waitForNodeLeaveCluster() (bool, error)  // this function is used to determine if a node has left the cluster
 }
@@ -80,6 +81,59 @@ func addDataNodeSuffix(nodeName string, replicaNumber int32) string {
 	return fmt.Sprintf("%s-%d", nodeName, replicaNumber)
 }
 
+func addStatefulSetPodSuffix(nodeName string, ordinal int32) string {
+	return fmt.Sprintf("%s-%d", nodeName, ordinal)
+}
+
+// getInitialMasterNodes returns the Elasticsearch node names of all master eligible
+// nodes used to bootstrap clusters without Zen discovery. Data nodes are named after
+// their deployment and the other nodes after their statefulset pod.
+func getInitialMasterNodes(cluster *api.Elasticsearch) []string {
+	masterNodes := []string{}
+
+	for _, node := range cluster.Spec.Nodes {
+		if !isMasterNode(node) || node.GenUUID == nil {
+			continue
+		}
+
+		nodeName := fmt.Sprintf("%s-%s", cluster.Name, getNodeSuffix(*node.GenUUID, getNodeRoleMap(node)))
+		for replicaIndex := int32(1); replicaIndex <= node.NodeCount; replicaIndex++ {
+			if isDataNode(node) {
+				masterNodes = append(masterNodes, addDataNodeSuffix(nodeName, replicaIndex))
+			} else {
+				masterNodes = append(masterNodes, addStatefulSetPodSuffix(nodeName, replicaIndex-1))
+			}
+		}
+	}
+
+	return masterNodes
+}
+
+// getElasticsearchNodeNames returns the names the node's pods join the cluster with
+func getElasticsearchNodeNames(node NodeTypeInterface) []string {
+	if sts, ok := node.(*statefulSetNode); ok && !usesZenDiscovery(getESImage()) {
+		names := []string{}
+		for ordinal := int32(0); ordinal < sts.replicas; ordinal++ {
+			names = append(names, addStatefulSetPodSuffix(sts.name(), ordinal))
+		}
+		return names
+	}
+
+	return []string{node.name()}
+}
+
+// isMasterNodeType returns true if the node's pods are master eligible
+func isMasterNodeType(node NodeTypeInterface) bool {
+	switch n := node.(type) {
+	case *statefulSetNode:
+		return n.self.Labels["es-node-master"] == "true"
+	case *deploymentNode:
+		return n.self.Labels["es-node-master"] == "true"
+	}
+
+	return false
+}
+
 // newDeploymentNode constructs deploymentNode struct for data nodes
 func newDeploymentNode(log logr.Logger, nodeName string, node api.ElasticsearchNode, cluster *api.Elasticsearch, roleMap map[api.ElasticsearchNodeRole]bool, client client.Client, esClient esclient.Client) NodeTypeInterface {
 	deploymentNode := deploymentNode{
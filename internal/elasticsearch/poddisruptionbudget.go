@@ -0,0 +1,119 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/poddisruptionbudget"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type disruptionBudget struct {
+	name           string
+	selector       map[string]string
+	pods           int32
+	maxUnavailable int32
+}
+
+// CreateOrUpdatePodDisruptionBudgets ensures the disruption budgets of the master and data pods.
+// The budgets only limit evictions, e.g. by node drains. The restarts of the operator delete the
+// pods themselves and the budgets are never relaxed for them, so that evictions during a restart
+// cannot take more masters or data nodes down than the budgets tolerate.
+func (er *ElasticsearchRequest) CreateOrUpdatePodDisruptionBudgets() error {
+	cluster := er.cluster
+
+	for _, budget := range er.newDisruptionBudgets() {
+		if budget.pods == 0 {
+			key := client.ObjectKey{Name: budget.name, Namespace: cluster.Namespace}
+			if err := poddisruptionbudget.Delete(context.TODO(), er.client, key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		pdb := poddisruptionbudget.New(budget.name, cluster.Namespace, appendDefaultLabel(cluster.Name, map[string]string{})).
+			WithSelector(budget.selector).
+			WithMaxUnavailable(budget.maxUnavailable).
+			Build()
+
+		cluster.AddOwnerRefTo(pdb)
+
+		err := poddisruptionbudget.CreateOrUpdate(context.TODO(), er.client, pdb, poddisruptionbudget.Equal, poddisruptionbudget.Mutate)
+		if err != nil {
+			return kverrors.Wrap(err, "failed to create or update elasticsearch poddisruptionbudget",
+				"cluster", cluster.Name,
+				"namespace", cluster.Namespace,
+			)
+		}
+	}
+
+	return nil
+}
+
+// newDisruptionBudgets returns the budget of the dedicated master pods, which keeps the
+// masters at quorum, and the budget of the data pods, which allows as many pods to be
+// unavailable as the redundancy policy keeps replicas of each shard.
+// Without redundancy every data pod holds the only copy of its shards, the budget then
+// lets one data pod go at a time so that drains can progress. A lone master holding data
+// gets no data budget at all, it could never be evicted otherwise.
+func (er *ElasticsearchRequest) newDisruptionBudgets() []disruptionBudget {
+	cluster := er.cluster
+
+	var dedicatedMasters, dataMasters int32
+	for _, node := range cluster.Spec.Nodes {
+		if !isMasterNode(node) {
+			continue
+		}
+		if isDataNode(node) {
+			dataMasters += node.NodeCount
+		} else {
+			dedicatedMasters += node.NodeCount
+		}
+	}
+
+	masters := getMasterCount(cluster)
+	masterTolerance := masters - (masters/2 + 1)
+
+	data := GetDataCount(cluster)
+	dataTolerance := int32(CalculateReplicaCount(cluster))
+	if cluster.Spec.RedundancyPolicy == api.ZeroRedundancy {
+		dataTolerance = 1
+	}
+	if dataMasters > 0 && dataTolerance > masterTolerance {
+		dataTolerance = masterTolerance
+	}
+	if dataTolerance == 0 && cluster.Spec.RedundancyPolicy == api.ZeroRedundancy {
+		data = 0
+	}
+
+	return []disruptionBudget{
+		{
+			name: fmt.Sprintf("%s-master", cluster.Name),
+			selector: map[string]string{
+				"cluster-name":   cluster.Name,
+				"es-node-master": "true",
+				"es-node-data":   "false",
+			},
+			pods:           dedicatedMasters,
+			maxUnavailable: boundedTolerance(masterTolerance, dedicatedMasters),
+		},
+		{
+			name:           fmt.Sprintf("%s-data", cluster.Name),
+			selector:       selectorForES("es-node-data", cluster.Name),
+			pods:           data,
+			maxUnavailable: boundedTolerance(dataTolerance, data),
+		},
+	}
+}
+
+func boundedTolerance(tolerance, pods int32) int32 {
+	if tolerance > pods {
+		return pods
+	}
+	if tolerance < 0 {
+		return 0
+	}
+	return tolerance
+}
@@ -0,0 +1,142 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestNewDisruptionBudgets(t *testing.T) {
+	masterData := []loggingv1.ElasticsearchNodeRole{loggingv1.ElasticsearchRoleMaster, loggingv1.ElasticsearchRoleData}
+	master := []loggingv1.ElasticsearchNodeRole{loggingv1.ElasticsearchRoleMaster}
+	data := []loggingv1.ElasticsearchNodeRole{loggingv1.ElasticsearchRoleData}
+
+	tests := []struct {
+		desc       string
+		policy     loggingv1.RedundancyPolicyType
+		nodes      []loggingv1.ElasticsearchNode
+		wantMaster [2]int32
+		wantData   [2]int32
+	}{
+		{
+			desc:       "single node without redundancy",
+			policy:     loggingv1.ZeroRedundancy,
+			nodes:      []loggingv1.ElasticsearchNode{{Roles: masterData, NodeCount: 1}},
+			wantMaster: [2]int32{0, 0},
+			wantData:   [2]int32{0, 0},
+		},
+		{
+			desc:   "data pool without redundancy drained one pod at a time",
+			policy: loggingv1.ZeroRedundancy,
+			nodes: []loggingv1.ElasticsearchNode{
+				{Roles: master, NodeCount: 3},
+				{Roles: data, NodeCount: 3},
+			},
+			wantMaster: [2]int32{3, 1},
+			wantData:   [2]int32{3, 1},
+		},
+		{
+			desc:       "data nodes holding masters keep the masters at quorum",
+			policy:     loggingv1.FullRedundancy,
+			nodes:      []loggingv1.ElasticsearchNode{{Roles: masterData, NodeCount: 3}},
+			wantMaster: [2]int32{0, 0},
+			wantData:   [2]int32{3, 1},
+		},
+		{
+			desc:   "dedicated masters and data pool sized from the redundancy policy",
+			policy: loggingv1.FullRedundancy,
+			nodes: []loggingv1.ElasticsearchNode{
+				{Roles: master, NodeCount: 3},
+				{Roles: data, NodeCount: 4},
+			},
+			wantMaster: [2]int32{3, 1},
+			wantData:   [2]int32{4, 3},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			er := &ElasticsearchRequest{
+				cluster: &loggingv1.Elasticsearch{
+					ObjectMeta: metav1.ObjectMeta{Name: "elasticsearch"},
+					Spec: loggingv1.ElasticsearchSpec{
+						RedundancyPolicy: test.policy,
+						Nodes:            test.nodes,
+					},
+				},
+			}
+
+			budgets := er.newDisruptionBudgets()
+			if got := [2]int32{budgets[0].pods, budgets[0].maxUnavailable}; got != test.wantMaster {
+				t.Errorf("master budget: got %v, want %v", got, test.wantMaster)
+			}
+			if got := [2]int32{budgets[1].pods, budgets[1].maxUnavailable}; got != test.wantData {
+				t.Errorf("data budget: got %v, want %v", got, test.wantData)
+			}
+		})
+	}
+}
+
+func TestCreateOrUpdatePodDisruptionBudgets(t *testing.T) {
+	cluster := &loggingv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "elasticsearch",
+			Namespace: "openshift-logging",
+		},
+		Spec: loggingv1.ElasticsearchSpec{
+			RedundancyPolicy: loggingv1.SingleRedundancy,
+			Nodes: []loggingv1.ElasticsearchNode{
+				{Roles: []loggingv1.ElasticsearchNodeRole{loggingv1.ElasticsearchRoleMaster, loggingv1.ElasticsearchRoleData}, NodeCount: 3},
+			},
+		},
+		Status: loggingv1.ElasticsearchStatus{
+			Conditions: []loggingv1.ClusterCondition{
+				{Type: loggingv1.Restarting, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	existing := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "elasticsearch-master",
+			Namespace: "openshift-logging",
+		},
+	}
+
+	k8sClient := fake.NewFakeClient(existing)
+	er := &ElasticsearchRequest{
+		client:  k8sClient,
+		cluster: cluster,
+		ll:      log.Log,
+	}
+
+	if err := er.CreateOrUpdatePodDisruptionBudgets(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	master := &policyv1.PodDisruptionBudget{}
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: "elasticsearch-master", Namespace: "openshift-logging"}, master)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the master budget without dedicated masters to be deleted, got %v", err)
+	}
+
+	data := &policyv1.PodDisruptionBudget{}
+	err = k8sClient.Get(context.TODO(), types.NamespacedName{Name: "elasticsearch-data", Namespace: "openshift-logging"}, data)
+	if err != nil {
+		t.Fatalf("expected the data budget to be created, got %v", err)
+	}
+	if got := data.Spec.MaxUnavailable.IntValue(); got != 1 {
+		t.Errorf("expected the data budget not to be relaxed while restarting, got %d", got)
+	}
+	if got := data.Spec.Selector.MatchLabels["es-node-data"]; got != "true" {
+		t.Errorf("expected the data budget to select data pods, got %q", got)
+	}
+}
@@ -177,6 +177,18 @@ func Reconcile(log logr.Logger, requestCluster *elasticsearchv1.Elasticsearch, r
 		return kverrors.Wrap(err, "Failed to reconcile Services for Elasticsearch cluster")
 	}
 
+	if err := elasticsearchRequest.CreateOrUpdatePodDisruptionBudgets(); err != nil {
+		return kverrors.Wrap(err, "Failed to reconcile PodDisruptionBudgets for Elasticsearch cluster")
+	}
+
+	if err := elasticsearchRequest.CreateOrUpdateNetworkPolicy(); err != nil {
+		return kverrors.Wrap(err, "Failed to reconcile NetworkPolicy for Elasticsearch cluster")
+	}
+
+	if err := elasticsearchRequest.CreateOrUpdateExposure(); err != nil {
+		return kverrors.Wrap(err, "Failed to reconcile Route or Ingress for Elasticsearch cluster")
+	}
+
 	if err := elasticsearchRequest.CreateOrUpdateDashboards(); err != nil {
 		return kverrors.Wrap(err, "Failed to reconcile Dashboards for Elasticsearch cluster")
 	}
@@ -0,0 +1,254 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/secret"
+	estypes "github.com/openshift/elasticsearch-operator/internal/types/elasticsearch"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	remoteClusterSettingsPrefix = "cluster.remote."
+	remoteClusterCAKey          = "ca.crt"
+)
+
+// updateRemoteClusters configures the seeds of the remote clusters of the spec as persistent
+// cluster settings, removes the remote clusters no longer in the spec and reports their connectivity
+func (er *ElasticsearchRequest) updateRemoteClusters() {
+	cluster := er.cluster
+
+	if len(cluster.Spec.RemoteClusters) == 0 && len(cluster.Status.RemoteClusters) == 0 {
+		return
+	}
+
+	if !er.AnyNodeReady() {
+		return
+	}
+
+	current, err := er.esClient.GetPersistentClusterSettings()
+	if err != nil {
+		er.L().Error(err, "Unable to get persistent cluster settings")
+		return
+	}
+
+	if changes := remoteClusterSettingsChanges(cluster, current); len(changes) > 0 {
+		if err := er.esClient.UpdatePersistentClusterSettings(changes); err != nil {
+			er.L().Error(err, "Unable to update remote cluster settings")
+			return
+		}
+	}
+
+	info := estypes.RemoteInfoResponse{}
+	if len(cluster.Spec.RemoteClusters) > 0 {
+		info, err = er.esClient.GetRemoteClusterInfo()
+		if err != nil {
+			er.L().Error(err, "Unable to get remote cluster info")
+			return
+		}
+	}
+
+	if err := updateRemoteClustersStatus(cluster, remoteClusterStatuses(cluster, info), er.client); err != nil {
+		er.L().Error(err, "Unable to set remote clusters status")
+	}
+}
+
+// remoteClusterSettingsChanges returns the persistent settings that need to be sent to the cluster
+// so that its remote clusters match the spec. The operator owns all cluster.remote settings,
+// remote clusters that are not in the spec are removed.
+func remoteClusterSettingsChanges(cluster *api.Elasticsearch, current map[string]interface{}) map[string]interface{} {
+	desired := map[string]interface{}{}
+	for _, rc := range cluster.Spec.RemoteClusters {
+		seeds := remoteClusterSeeds(cluster, rc)
+		if len(seeds) == 0 {
+			continue
+		}
+		desired[remoteClusterSettingsPrefix+rc.Name+".seeds"] = seeds
+		desired[remoteClusterSettingsPrefix+rc.Name+".skip_unavailable"] = strconv.FormatBool(rc.SkipUnavailable)
+	}
+
+	changes := map[string]interface{}{}
+	for key, value := range desired {
+		if currentValue, ok := current[key]; !ok || fmt.Sprint(currentValue) != fmt.Sprint(value) {
+			changes[key] = value
+		}
+	}
+
+	for key := range current {
+		if !strings.HasPrefix(key, remoteClusterSettingsPrefix) {
+			continue
+		}
+		if _, ok := desired[key]; !ok {
+			changes[key] = nil
+		}
+	}
+
+	return changes
+}
+
+// remoteClusterSeeds returns the transport addresses of the remote cluster. Elasticsearch CRs
+// are reached through the transport service of the cluster.
+func remoteClusterSeeds(cluster *api.Elasticsearch, rc api.ElasticsearchRemoteCluster) []string {
+	if rc.ElasticsearchRef == nil {
+		return rc.Seeds
+	}
+
+	return []string{
+		fmt.Sprintf("%s-cluster.%s.svc:9300", rc.ElasticsearchRef.Name, referenceNamespace(cluster, rc.ElasticsearchRef)),
+	}
+}
+
+func remoteClusterStatuses(cluster *api.Elasticsearch, info estypes.RemoteInfoResponse) []api.ElasticsearchRemoteClusterStatus {
+	if len(cluster.Spec.RemoteClusters) == 0 {
+		return nil
+	}
+
+	statuses := make([]api.ElasticsearchRemoteClusterStatus, 0, len(cluster.Spec.RemoteClusters))
+	for _, rc := range cluster.Spec.RemoteClusters {
+		status := api.ElasticsearchRemoteClusterStatus{
+			Name:  rc.Name,
+			Seeds: remoteClusterSeeds(cluster, rc),
+		}
+		if remote, ok := info[rc.Name]; ok {
+			status.Connected = remote.Connected
+			status.NumNodesConnected = remote.NumNodesConnected
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+func referenceNamespace(cluster *api.Elasticsearch, ref *api.ElasticsearchReference) string {
+	if ref.Namespace == "" {
+		return cluster.Namespace
+	}
+	return ref.Namespace
+}
+
+// transportCABundle returns the CA certificates trusted by the transport layer when the spec lists
+// remote or trusted clusters: the CA of the cluster followed by the CAs of the other clusters found.
+// It is empty when the spec trusts no other cluster.
+func (er *ElasticsearchRequest) transportCABundle() (string, error) {
+	cluster := er.cluster
+
+	sources := remoteCASources(cluster)
+	if len(sources) == 0 {
+		return "", nil
+	}
+
+	key := client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}
+	s, err := secret.Get(context.TODO(), er.client, key)
+	if err != nil {
+		return "", kverrors.Wrap(err, "failed to get the CA certificate of the cluster",
+			"cluster", cluster.Name,
+			"namespace", cluster.Namespace,
+		)
+	}
+
+	bundle := []string{pemBlock(s.Data[esAdminCAName])}
+	for _, source := range sortedCASources(sources) {
+		s, err := secret.Get(context.TODO(), er.client, source.key)
+		if err != nil {
+			if !apierrors.IsNotFound(kverrors.Root(err)) {
+				return "", err
+			}
+			er.L().Info("CA certificate of remote cluster not found", "secret", source.key.String())
+			continue
+		}
+
+		ca := pemBlock(s.Data[source.dataKey])
+		if ca == "" || slices.Contains(bundle, ca) {
+			continue
+		}
+		bundle = append(bundle, ca)
+	}
+
+	return strings.Join(bundle, ""), nil
+}
+
+// hasTransportCABundle returns true if the configmap of the cluster carries a transport CA bundle
+func hasTransportCABundle(cluster *api.Elasticsearch) bool {
+	return len(remoteCASources(cluster)) > 0
+}
+
+// mountTransportCABundle adds the transport CA bundle to the configmap items of nodes which
+// mount only selected items of the configmap
+func mountTransportCABundle(template *v1.PodTemplateSpec) {
+	for _, vol := range template.Spec.Volumes {
+		if vol.Name != "elasticsearch-config" || vol.ConfigMap == nil || len(vol.ConfigMap.Items) == 0 {
+			continue
+		}
+		vol.ConfigMap.Items = append(vol.ConfigMap.Items, v1.KeyToPath{
+			Key:  transportCABundle,
+			Path: transportCABundle,
+		})
+	}
+}
+
+type caSource struct {
+	key     client.ObjectKey
+	dataKey string
+}
+
+// remoteCASources returns the secrets with the CAs of the remote clusters and of the trusted
+// clusters of the spec. Only the spec of this cluster grants trust, the clusters referencing
+// it as remote cluster are not looked up.
+func remoteCASources(cluster *api.Elasticsearch) map[string]caSource {
+	sources := map[string]caSource{}
+
+	add := func(name, namespace, dataKey string) {
+		if name == cluster.Name && namespace == cluster.Namespace {
+			return
+		}
+		key := client.ObjectKey{Name: name, Namespace: namespace}
+		sources[key.String()+"/"+dataKey] = caSource{key: key, dataKey: dataKey}
+	}
+
+	for _, rc := range cluster.Spec.RemoteClusters {
+		switch {
+		case rc.ElasticsearchRef != nil:
+			add(rc.ElasticsearchRef.Name, referenceNamespace(cluster, rc.ElasticsearchRef), esAdminCAName)
+		case rc.CASecretName != "":
+			add(rc.CASecretName, cluster.Namespace, remoteClusterCAKey)
+		}
+	}
+
+	for i := range cluster.Spec.TrustedClusters {
+		ref := &cluster.Spec.TrustedClusters[i]
+		add(ref.Name, referenceNamespace(cluster, ref), esAdminCAName)
+	}
+
+	return sources
+}
+
+func sortedCASources(sources map[string]caSource) []caSource {
+	keys := make([]string, 0, len(sources))
+	for key := range sources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sorted := make([]caSource, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, sources[key])
+	}
+	return sorted
+}
+
+func pemBlock(data []byte) string {
+	pem := strings.TrimSpace(string(data))
+	if pem == "" {
+		return ""
+	}
+	return pem + "\n"
+}
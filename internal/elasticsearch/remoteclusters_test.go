@@ -0,0 +1,245 @@
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	estypes "github.com/openshift/elasticsearch-operator/internal/types/elasticsearch"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestRemoteClusterSettingsChanges(t *testing.T) {
+	cluster := &loggingv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "elasticsearch-app",
+			Namespace: "openshift-logging",
+		},
+		Spec: loggingv1.ElasticsearchSpec{
+			RemoteClusters: []loggingv1.ElasticsearchRemoteCluster{
+				{
+					Name:             "infra",
+					ElasticsearchRef: &loggingv1.ElasticsearchReference{Name: "elasticsearch-infra"},
+				},
+				{
+					Name:            "external",
+					Seeds:           []string{"es.example.com:9300"},
+					SkipUnavailable: true,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc    string
+		current map[string]interface{}
+		want    map[string]interface{}
+	}{
+		{
+			desc:    "new remote clusters",
+			current: map[string]interface{}{},
+			want: map[string]interface{}{
+				"cluster.remote.infra.seeds":               []string{"elasticsearch-infra-cluster.openshift-logging.svc:9300"},
+				"cluster.remote.infra.skip_unavailable":    "false",
+				"cluster.remote.external.seeds":            []string{"es.example.com:9300"},
+				"cluster.remote.external.skip_unavailable": "true",
+			},
+		},
+		{
+			desc: "configured remote clusters",
+			current: map[string]interface{}{
+				"cluster.remote.infra.seeds":               []interface{}{"elasticsearch-infra-cluster.openshift-logging.svc:9300"},
+				"cluster.remote.infra.skip_unavailable":    "false",
+				"cluster.remote.external.seeds":            []interface{}{"es.example.com:9300"},
+				"cluster.remote.external.skip_unavailable": "true",
+			},
+			want: map[string]interface{}{},
+		},
+		{
+			desc: "remote cluster removed from the spec",
+			current: map[string]interface{}{
+				"cluster.remote.infra.seeds":               []interface{}{"elasticsearch-infra-cluster.openshift-logging.svc:9300"},
+				"cluster.remote.infra.skip_unavailable":    "false",
+				"cluster.remote.external.seeds":            []interface{}{"es.example.com:9300"},
+				"cluster.remote.external.skip_unavailable": "true",
+				"cluster.remote.audit.seeds":               []interface{}{"audit:9300"},
+				"cluster.routing.allocation.enable":        "all",
+			},
+			want: map[string]interface{}{
+				"cluster.remote.audit.seeds": nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			got := remoteClusterSettingsChanges(cluster, test.current)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("changes mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRemoteClusterStatuses(t *testing.T) {
+	cluster := &loggingv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-logging"},
+		Spec: loggingv1.ElasticsearchSpec{
+			RemoteClusters: []loggingv1.ElasticsearchRemoteCluster{
+				{Name: "infra", ElasticsearchRef: &loggingv1.ElasticsearchReference{Name: "elasticsearch-infra", Namespace: "infra"}},
+				{Name: "external", Seeds: []string{"es.example.com:9300"}},
+			},
+		},
+	}
+	info := estypes.RemoteInfoResponse{
+		"infra": {Connected: true, NumNodesConnected: 3},
+	}
+
+	want := []loggingv1.ElasticsearchRemoteClusterStatus{
+		{Name: "infra", Seeds: []string{"elasticsearch-infra-cluster.infra.svc:9300"}, Connected: true, NumNodesConnected: 3},
+		{Name: "external", Seeds: []string{"es.example.com:9300"}},
+	}
+	if diff := cmp.Diff(want, remoteClusterStatuses(cluster, info)); diff != "" {
+		t.Errorf("statuses mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTransportCABundle(t *testing.T) {
+	utilruntime.Must(loggingv1.AddToScheme(scheme.Scheme))
+
+	caSecret := func(name, namespace, key, ca string) client.Object {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       map[string][]byte{key: []byte(ca)},
+		}
+	}
+
+	app := &loggingv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Name: "elasticsearch-app", Namespace: "openshift-logging"},
+		Spec: loggingv1.ElasticsearchSpec{
+			RemoteClusters: []loggingv1.ElasticsearchRemoteCluster{
+				{Name: "infra", ElasticsearchRef: &loggingv1.ElasticsearchReference{Name: "elasticsearch-infra"}},
+				{Name: "external", Seeds: []string{"es.example.com:9300"}, CASecretName: "external-ca"},
+			},
+		},
+	}
+	infra := &loggingv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Name: "elasticsearch-infra", Namespace: "openshift-logging"},
+	}
+	trustingInfra := infra.DeepCopy()
+	trustingInfra.Spec.TrustedClusters = []loggingv1.ElasticsearchReference{{Name: "elasticsearch-app"}}
+	standalone := &loggingv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Name: "elasticsearch", Namespace: "other"},
+	}
+	trustingMissing := standalone.DeepCopy()
+	trustingMissing.Spec.TrustedClusters = []loggingv1.ElasticsearchReference{{Name: "missing"}}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			app, infra, standalone,
+			caSecret("elasticsearch-app", "openshift-logging", "admin-ca", "app-ca"),
+			caSecret("elasticsearch-infra", "openshift-logging", "admin-ca", "infra-ca"),
+			caSecret("external-ca", "openshift-logging", "ca.crt", "external-ca\n"),
+			caSecret("elasticsearch", "other", "admin-ca", "standalone-ca"),
+		).
+		Build()
+
+	tests := []struct {
+		desc    string
+		cluster *loggingv1.Elasticsearch
+		want    string
+	}{
+		{
+			desc:    "cluster with remote clusters",
+			cluster: app,
+			want:    "app-ca\ninfra-ca\nexternal-ca\n",
+		},
+		{
+			desc:    "remote cluster of a cluster it does not trust",
+			cluster: infra,
+			want:    "",
+		},
+		{
+			desc:    "remote cluster of a trusted cluster",
+			cluster: trustingInfra,
+			want:    "infra-ca\napp-ca\n",
+		},
+		{
+			desc:    "cluster without remote clusters",
+			cluster: standalone,
+			want:    "",
+		},
+		{
+			desc:    "trusted cluster without CA",
+			cluster: trustingMissing,
+			want:    "standalone-ca\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			er := &ElasticsearchRequest{
+				client:  k8sClient,
+				cluster: test.cluster,
+				ll:      log.Log,
+			}
+
+			got, err := er.transportCABundle()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("got bundle %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMountTransportCABundle(t *testing.T) {
+	uuid := "deadbeef"
+	node := loggingv1.ElasticsearchNode{
+		GenUUID: &uuid,
+		Config:  map[string]string{"node.attr.zone": "zone-a"},
+	}
+
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "elasticsearch-config",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: newConfigVolumeSource("elasticsearch", node),
+					},
+				},
+			},
+		},
+	}
+
+	source := template.Spec.Volumes[0].ConfigMap
+	if source.Optional != nil && *source.Optional {
+		t.Errorf("expected the config volume to be required")
+	}
+	for _, item := range source.Items {
+		if item.Key == transportCABundle {
+			t.Errorf("expected no transport CA bundle item without a bundle")
+		}
+	}
+
+	mountTransportCABundle(&template)
+
+	items := template.Spec.Volumes[0].ConfigMap.Items
+	want := corev1.KeyToPath{Key: transportCABundle, Path: transportCABundle}
+	if got := items[len(items)-1]; got != want {
+		t.Errorf("expected the transport CA bundle to be mounted, got %v", items)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/openshift/elasticsearch-operator/internal/elasticsearch/esclient"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/configmap"
-	"github.com/openshift/elasticsearch-operator/internal/manifests/pod"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/secret"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/statefulset"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -56,6 +55,14 @@ func (n *statefulSetNode) populateReference(nodeName string, node api.Elasticsea
 		nodeName, cluster.Name, cluster.Namespace, node,
 		cluster.Spec.Spec, labels, roleMap, client, logConfig,
 	)
+	if hasTransportCABundle(cluster) {
+		mountTransportCABundle(&template)
+	}
+
+	if !usesZenDiscovery(getESImage()) {
+		// cluster.initial_master_nodes needs a distinct name for every pod
+		setPodNameAsNodeName(&template)
+	}
 
 	sts := statefulset.New(nodeName, cluster.Namespace, labels, replicas).
 		WithSelector(metav1.LabelSelector{
@@ -82,8 +89,31 @@ func (n *statefulSetNode) populateReference(nodeName string, node api.Elasticsea
 	n.esClient = esClient
 }
 
+// setPodNameAsNodeName names the Elasticsearch node after its pod instead of the statefulset
+func setPodNameAsNodeName(template *v1.PodTemplateSpec) {
+	for i, container := range template.Spec.Containers {
+		if container.Name != "elasticsearch" {
+			continue
+		}
+
+		for j, env := range container.Env {
+			if env.Name == "DC_NAME" {
+				template.Spec.Containers[i].Env[j] = v1.EnvVar{
+					Name: "DC_NAME",
+					ValueFrom: &v1.EnvVarSource{
+						FieldRef: &v1.ObjectFieldSelector{
+							FieldPath: "metadata.name",
+						},
+					},
+				}
+			}
+		}
+	}
+}
+
 func (n *statefulSetNode) updateReference(desired NodeTypeInterface) {
 	n.self = desired.(*statefulSetNode).self
+	n.replicas = desired.(*statefulSetNode).replicas
 }
 
 func (n *statefulSetNode) scaleDown() error {
@@ -272,7 +302,7 @@ func (n *statefulSetNode) create() error {
 
 func (n *statefulSetNode) executeUpdate() error {
 	equalFunc := func(current, desired *apps.StatefulSet) bool {
-		return pod.ArePodTemplateSpecEqual(current.Spec.Template, desired.Spec.Template)
+		return arePodTemplateSpecEqual(current.Spec.Template, desired.Spec.Template)
 	}
 
 	mutateFunc := func(current, desired *apps.StatefulSet) {
@@ -289,6 +319,35 @@ func (n *statefulSetNode) executeUpdate() error {
 	return nil
 }
 
+// progressProxyChanges updates the statefulset when only the proxy image changed.
+// The partition is kept at the replica count so no pod is recreated by the update,
+// other proxy changes are left to the rolling restart of the cluster.
+func (n *statefulSetNode) progressProxyChanges() error {
+	key := client.ObjectKey{Name: n.name(), Namespace: n.self.Namespace}
+	current, err := statefulset.Get(context.TODO(), n.client, key)
+	if err != nil {
+		return err
+	}
+
+	if !isProxyImageOnlyChange(current.Spec.Template, n.self.Spec.Template) {
+		return nil
+	}
+
+	n.L().Info("Rolling out proxy only changes")
+
+	if err := n.setPartition(*current.Spec.Replicas); err != nil {
+		return err
+	}
+
+	if err := n.executeUpdate(); err != nil {
+		return err
+	}
+
+	return updateProxyContainerImage(context.TODO(), n.client, n.self.Namespace, map[string]string{
+		"node-name": n.name(),
+	}, getESProxyImage())
+}
+
 func (n *statefulSetNode) refreshHashes() {
 	key := client.ObjectKey{Name: n.clusterName, Namespace: n.self.Namespace}
 
@@ -303,6 +362,9 @@ func (n *statefulSetNode) refreshHashes() {
 	}
 }
 
+// scale sets the replicas of the statefulset to the node count of the spec. Without Zen discovery
+// the departing master pods are excluded from the voting configuration first, the exclusions are
+// cleared once they left the cluster.
 func (n *statefulSetNode) scale() {
 	key := client.ObjectKey{Name: n.name(), Namespace: n.self.Namespace}
 	sts, err := statefulset.Get(context.TODO(), n.client, key)
@@ -310,14 +372,26 @@ func (n *statefulSetNode) scale() {
 		return
 	}
 
-	if *sts.Spec.Replicas != *n.self.Spec.Replicas {
-		n.self.Spec.Replicas = sts.Spec.Replicas
-		n.L().Info("Resource has different container replicas than desired")
+	current := *sts.Spec.Replicas
+	if current == n.replicas {
+		return
+	}
+	n.L().Info("Resource has different container replicas than desired", "current", current, "desired", n.replicas)
 
-		if err := n.setReplicaCount(*n.self.Spec.Replicas); err != nil {
-			n.L().Error(err, "unable to set replicate count")
+	if n.replicas < current && isMasterNodeType(n) && !usesZenDiscovery(getESImage()) {
+		departing := []string{}
+		for ordinal := n.replicas; ordinal < current; ordinal++ {
+			departing = append(departing, addStatefulSetPodSuffix(n.name(), ordinal))
+		}
+		if err := n.esClient.AddVotingConfigExclusions(departing); err != nil {
+			n.L().Error(err, "unable to exclude departing master nodes from voting configuration", "nodes", departing)
+			return
 		}
 	}
+
+	if err := n.setReplicaCount(n.replicas); err != nil {
+		n.L().Error(err, "unable to set replicate count")
+	}
 }
 
 func (n *statefulSetNode) isChanged() bool {
@@ -327,7 +401,7 @@ func (n *statefulSetNode) isChanged() bool {
 		return false
 	}
 
-	return !pod.ArePodTemplateSpecEqual(sts.Spec.Template, n.self.Spec.Template)
+	return !arePodTemplateSpecEqual(sts.Spec.Template, n.self.Spec.Template)
 }
 
 func (n *statefulSetNode) progressNodeChanges() error {
@@ -8,6 +8,7 @@ import (
 
 	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/pod"
+	"github.com/openshift/elasticsearch-operator/internal/utils/settings"
 
 	"github.com/ViaQ/logerr/v2/kverrors"
 	v1 "k8s.io/api/core/v1"
@@ -994,6 +995,166 @@ func updateInvalidUUIDChangeCondition(cluster *api.Elasticsearch, value v1.Condi
 	)
 }
 
+func updateInvalidJVMSettingsCondition(cluster *api.Elasticsearch, value v1.ConditionStatus, message string, client client.Client) error {
+	var reason string
+	if value == v1.ConditionTrue {
+		reason = "Invalid Settings"
+	} else {
+		reason = ""
+	}
+
+	return updateConditionWithRetry(
+		cluster,
+		value,
+		func(status *api.ElasticsearchStatus, value v1.ConditionStatus) bool {
+			return updateESNodeCondition(&cluster.Status, &api.ClusterCondition{
+				Type:    api.InvalidJVMSettings,
+				Status:  value,
+				Reason:  reason,
+				Message: message,
+			})
+		},
+		client,
+	)
+}
+
+func updateInvalidConfigCondition(cluster *api.Elasticsearch, value v1.ConditionStatus, message string, client client.Client) error {
+	var reason string
+	if value == v1.ConditionTrue {
+		reason = "Invalid Settings"
+	} else {
+		reason = ""
+	}
+
+	return updateConditionWithRetry(
+		cluster,
+		value,
+		func(status *api.ElasticsearchStatus, value v1.ConditionStatus) bool {
+			return updateESNodeCondition(&cluster.Status, &api.ClusterCondition{
+				Type:    api.InvalidConfig,
+				Status:  value,
+				Reason:  reason,
+				Message: message,
+			})
+		},
+		client,
+	)
+}
+
+func updateInvalidPodExtensionsCondition(cluster *api.Elasticsearch, value v1.ConditionStatus, message string, client client.Client) error {
+	var reason string
+	if value == v1.ConditionTrue {
+		reason = "Invalid Pod Extensions"
+	} else {
+		reason = ""
+	}
+
+	return updateConditionWithRetry(
+		cluster,
+		value,
+		func(status *api.ElasticsearchStatus, value v1.ConditionStatus) bool {
+			return updateESNodeCondition(&cluster.Status, &api.ClusterCondition{
+				Type:    api.InvalidPodExtensions,
+				Status:  value,
+				Reason:  reason,
+				Message: message,
+			})
+		},
+		client,
+	)
+}
+
+func updateInvalidClusterSettingsCondition(cluster *api.Elasticsearch, value v1.ConditionStatus, reason, message string, client client.Client) error {
+	return updateConditionWithRetry(
+		cluster,
+		value,
+		func(status *api.ElasticsearchStatus, value v1.ConditionStatus) bool {
+			return updateESNodeCondition(&cluster.Status, &api.ClusterCondition{
+				Type:    api.InvalidClusterSettings,
+				Status:  value,
+				Reason:  reason,
+				Message: message,
+			})
+		},
+		client,
+	)
+}
+
+// updateAppliedClusterSettings records the persistent cluster settings applied from the spec
+// and reports them in the ClusterSettingsApplied condition
+func updateAppliedClusterSettings(cluster *api.Elasticsearch, clusterSettings map[string]string, client client.Client) error {
+	value := v1.ConditionFalse
+	var message string
+	if len(clusterSettings) == 0 {
+		clusterSettings = nil
+	} else {
+		value = v1.ConditionTrue
+
+		applied := []string{}
+		for _, key := range settings.SortedKeys(clusterSettings) {
+			applied = append(applied, fmt.Sprintf("%s=%s", key, clusterSettings[key]))
+		}
+		message = fmt.Sprintf("Applied persistent cluster settings: %s", strings.Join(applied, ", "))
+	}
+
+	return updateConditionWithRetry(
+		cluster,
+		value,
+		func(status *api.ElasticsearchStatus, value v1.ConditionStatus) bool {
+			changed := !reflect.DeepEqual(status.ClusterSettings, clusterSettings)
+			if changed {
+				status.ClusterSettings = clusterSettings
+			}
+
+			return updateESNodeCondition(status, &api.ClusterCondition{
+				Type:    api.ClusterSettingsApplied,
+				Status:  value,
+				Reason:  "Applied",
+				Message: message,
+			}) || changed
+		},
+		client,
+	)
+}
+
+// updateLoggingStatus records the logger levels applied from the spec
+func updateLoggingStatus(cluster *api.Elasticsearch, logging *api.ElasticsearchLoggingStatus, client client.Client) error {
+	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := client.Get(context.TODO(), types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, cluster); err != nil {
+			return kverrors.Wrap(err, "failed to get elasticsearch",
+				"cluster", cluster.Name,
+			)
+		}
+
+		if reflect.DeepEqual(cluster.Status.Logging, logging) {
+			return nil
+		}
+
+		cluster.Status.Logging = logging
+		return client.Status().Update(context.TODO(), cluster)
+	})
+	return kverrors.Wrap(retryErr, "failed to update elasticsearch status")
+}
+
+// updateRemoteClustersStatus records the connectivity of the remote clusters
+func updateRemoteClustersStatus(cluster *api.Elasticsearch, remoteClusters []api.ElasticsearchRemoteClusterStatus, client client.Client) error {
+	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := client.Get(context.TODO(), types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, cluster); err != nil {
+			return kverrors.Wrap(err, "failed to get elasticsearch",
+				"cluster", cluster.Name,
+			)
+		}
+
+		if reflect.DeepEqual(cluster.Status.RemoteClusters, remoteClusters) {
+			return nil
+		}
+
+		cluster.Status.RemoteClusters = remoteClusters
+		return client.Status().Update(context.TODO(), cluster)
+	})
+	return kverrors.Wrap(retryErr, "failed to update elasticsearch status")
+}
+
 func updateInvalidReplicationCondition(status *api.ElasticsearchStatus, value v1.ConditionStatus) bool {
 	var message string
 	var reason string
@@ -6,6 +6,8 @@ import (
 
 	"github.com/ViaQ/logerr/v2/kverrors"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	api "github.com/openshift/elasticsearch-operator/apis/logging/v1"
 )
@@ -14,6 +16,7 @@ const (
 	loglevelAnnotation          = "elasticsearch.openshift.io/loglevel"
 	serverLogAppenderAnnotation = "elasticsearch.openshift.io/develLogAppender"
 	serverLoglevelAnnotation    = "elasticsearch.openshift.io/esloglevel"
+	extraVolumesHashAnnotation  = "elasticsearch.openshift.io/extra-volumes-hash"
 )
 
 type LogConfig struct {
@@ -23,10 +26,14 @@ type LogConfig struct {
 	ServerLoglevel string
 	// ServerAppender where to log messages
 	ServerAppender string
+	// SlowLogConsole writes the slow logs to the console instead of the slow log files
+	SlowLogConsole bool
+	// Loggers are the levels of individual loggers by logger name
+	Loggers map[string]api.LogLevel
 }
 
 func getLogConfig(annotations map[string]string) LogConfig {
-	config := LogConfig{"info", "info", "console"}
+	config := LogConfig{"info", "info", "console", false, nil}
 	if value, found := annotations[loglevelAnnotation]; found {
 		if strings.TrimSpace(value) != "" {
 			config.LogLevel = value
@@ -243,6 +250,129 @@ func (er *ElasticsearchRequest) isValidConf() error {
 		}
 	}
 
+	if err := validateJVMSettings(dpl); err != nil {
+		if err := updateInvalidJVMSettingsCondition(dpl, v1.ConditionTrue, err.Error(), er.client); err != nil {
+			return kverrors.Wrap(err, "failed to set JVM settings status")
+		}
+		return kverrors.Wrap(err, "invalid JVM settings")
+	} else {
+		if err := updateInvalidJVMSettingsCondition(dpl, v1.ConditionFalse, "", er.client); err != nil {
+			return kverrors.Wrap(err, "failed to set JVM settings status")
+		}
+	}
+
+	if err := validateNodeConfigs(dpl); err != nil {
+		if err := updateInvalidConfigCondition(dpl, v1.ConditionTrue, err.Error(), er.client); err != nil {
+			return kverrors.Wrap(err, "failed to set config status")
+		}
+		return kverrors.Wrap(err, "invalid elasticsearch.yml config")
+	} else {
+		if err := updateInvalidConfigCondition(dpl, v1.ConditionFalse, "", er.client); err != nil {
+			return kverrors.Wrap(err, "failed to set config status")
+		}
+	}
+
+	if err := validatePodExtensions(dpl); err != nil {
+		if err := updateInvalidPodExtensionsCondition(dpl, v1.ConditionTrue, err.Error(), er.client); err != nil {
+			return kverrors.Wrap(err, "failed to set pod extensions status")
+		}
+		return kverrors.Wrap(err, "invalid pod extensions")
+	} else {
+		if err := updateInvalidPodExtensionsCondition(dpl, v1.ConditionFalse, "", er.client); err != nil {
+			return kverrors.Wrap(err, "failed to set pod extensions status")
+		}
+	}
+
+	return nil
+}
+
+// validatePodExtensions ensures that the extra volumes, volume mounts, init containers and
+// sidecars of every node do not collide with the ones managed by the operator
+func validatePodExtensions(dpl *api.Elasticsearch) error {
+	operatorVolumes := sets.NewString(operatorVolumeNames(dpl.Name)...)
+	operatorMountPaths := sets.NewString("/elasticsearch/persistent", elasticsearchConfigPath, elasticsearchCertsPath)
+
+	for _, node := range dpl.Spec.Nodes {
+		for _, volume := range appendVolumes(node.ExtraVolumes, dpl.Spec.Spec.ExtraVolumes) {
+			if operatorVolumes.Has(volume.Name) {
+				return kverrors.New("extra volume name is managed by the operator",
+					"roles", node.Roles,
+					"volume", volume.Name)
+			}
+		}
+
+		for _, mount := range appendVolumeMounts(node.ExtraVolumeMounts, dpl.Spec.Spec.ExtraVolumeMounts) {
+			if operatorMountPaths.Has(mount.MountPath) {
+				return kverrors.New("extra volume mount path is managed by the operator",
+					"roles", node.Roles,
+					"mount_path", mount.MountPath)
+			}
+		}
+
+		containerNames := sets.NewString("elasticsearch", "proxy")
+		containers := appendContainers(node.InitContainers, dpl.Spec.Spec.InitContainers)
+		containers = append(containers, appendContainers(node.Sidecars, dpl.Spec.Spec.Sidecars)...)
+		for _, container := range containers {
+			if containerNames.Has(container.Name) {
+				return kverrors.New("container name is already used by the operator or another container",
+					"roles", node.Roles,
+					"container", container.Name)
+			}
+			containerNames.Insert(container.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateNodeConfigs ensures that neither the common nor the node configs override operator owned settings
+func validateNodeConfigs(dpl *api.Elasticsearch) error {
+	if err := configPolicy.Validate(dpl.Spec.Spec.Config); err != nil {
+		return err
+	}
+
+	for _, node := range dpl.Spec.Nodes {
+		if err := configPolicy.Validate(node.Config); err != nil {
+			return kverrors.Wrap(err, "invalid node config", "roles", node.Roles)
+		}
+	}
+
+	return nil
+}
+
+// validateJVMSettings ensures that the heap of every node fits into the memory limit
+// of its Elasticsearch container, that custom heaps are not below the minimum heap size
+// and that heap options are not passed as JVM options
+func validateJVMSettings(dpl *api.Elasticsearch) error {
+	for _, node := range dpl.Spec.Nodes {
+		jvm := newJVMSpec(node.JVM, dpl.Spec.Spec.JVM)
+
+		for _, option := range jvm.Options {
+			if strings.HasPrefix(option, "-Xms") || strings.HasPrefix(option, "-Xmx") {
+				return kverrors.New("heap options are not allowed in JVM options, use heapPercent or heapSize instead",
+					"option", option)
+			}
+		}
+
+		resources := newESResourceRequirements(node.Resources, dpl.Spec.Spec.Resources)
+		memoryLimit := resources.Limits.Memory()
+		heapSize := getHeapSize(*memoryLimit, jvm)
+
+		if (jvm.HeapPercent != 0 || jvm.HeapSize != nil) && heapSize.Value() < minHeapSize {
+			return kverrors.New("heap size must be at least the minimum heap size",
+				"roles", node.Roles,
+				"heap_size", heapSize.String(),
+				"min_heap_size", resource.NewQuantity(minHeapSize, resource.BinarySI).String())
+		}
+
+		if heapSize.Sign() <= 0 || heapSize.Cmp(*memoryLimit) >= 0 {
+			return kverrors.New("heap size must be greater than zero and less than the memory limit",
+				"roles", node.Roles,
+				"heap_size", heapSize.String(),
+				"memory_limit", memoryLimit.String())
+		}
+	}
+
 	return nil
 }
 
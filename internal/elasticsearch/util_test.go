@@ -10,6 +10,7 @@ import (
 	"github.com/openshift/elasticsearch-operator/internal/utils/comparators"
 	"github.com/openshift/elasticsearch-operator/test/helpers"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -341,6 +342,164 @@ func TestSingleNodeNoReplicaCount(t *testing.T) {
 	}
 }
 
+func TestValidateJVMSettings(t *testing.T) {
+	heapSize := resource.MustParse("31Gi")
+	tooLarge := resource.MustParse("64Gi")
+	tooSmall := resource.MustParse("100Mi")
+
+	tests := []struct {
+		desc    string
+		common  *api.ElasticsearchJVMSpec
+		node    *api.ElasticsearchJVMSpec
+		wantErr bool
+	}{
+		{
+			desc: "no jvm settings",
+		},
+		{
+			desc:   "heap percent from common spec",
+			common: &api.ElasticsearchJVMSpec{HeapPercent: 75},
+		},
+		{
+			desc: "heap size below memory limit",
+			node: &api.ElasticsearchJVMSpec{HeapSize: &heapSize},
+		},
+		{
+			desc:    "heap size above memory limit",
+			common:  &api.ElasticsearchJVMSpec{HeapPercent: 50},
+			node:    &api.ElasticsearchJVMSpec{HeapSize: &tooLarge},
+			wantErr: true,
+		},
+		{
+			desc:    "heap size below minimum",
+			node:    &api.ElasticsearchJVMSpec{HeapSize: &tooSmall},
+			wantErr: true,
+		},
+		{
+			desc:    "heap options in jvm options",
+			node:    &api.ElasticsearchJVMSpec{Options: []string{"-XX:+UseG1GC", "-Xmx8g"}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			esCR := &api.Elasticsearch{
+				Spec: api.ElasticsearchSpec{
+					Spec: api.ElasticsearchNodeSpec{
+						Resources: v1.ResourceRequirements{
+							Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Gi")},
+						},
+						JVM: test.common,
+					},
+					Nodes: []api.ElasticsearchNode{
+						{
+							Roles:     []api.ElasticsearchNodeRole{"data"},
+							NodeCount: 1,
+							JVM:       test.node,
+						},
+					},
+				},
+			}
+
+			err := validateJVMSettings(esCR)
+			if test.wantErr && err == nil {
+				t.Errorf("Expected JVM settings to be invalid")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("Expected JVM settings to be valid, got %s", err)
+			}
+		})
+	}
+}
+
+func TestValidatePodExtensions(t *testing.T) {
+	tests := []struct {
+		desc    string
+		common  api.ElasticsearchNodeSpec
+		node    api.ElasticsearchNode
+		wantErr bool
+	}{
+		{
+			desc: "no extensions",
+		},
+		{
+			desc: "extra volume, mount and sidecar",
+			common: api.ElasticsearchNodeSpec{
+				ExtraVolumes:      []v1.Volume{{Name: "synonyms"}},
+				ExtraVolumeMounts: []v1.VolumeMount{{Name: "synonyms", MountPath: "/etc/elasticsearch/synonyms"}},
+			},
+			node: api.ElasticsearchNode{
+				Sidecars: []v1.Container{{Name: "log-shipper"}},
+			},
+		},
+		{
+			desc: "extra volume named as an operator volume",
+			node: api.ElasticsearchNode{
+				ExtraVolumes: []v1.Volume{{Name: "certificates"}},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "extra volume named as the metrics volume",
+			common: api.ElasticsearchNodeSpec{
+				ExtraVolumes: []v1.Volume{{Name: "elasticsearch-metrics"}},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "extra volume mount over the operator storage",
+			node: api.ElasticsearchNode{
+				ExtraVolumeMounts: []v1.VolumeMount{{Name: "data", MountPath: "/elasticsearch/persistent"}},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "sidecar named as an operator container",
+			node: api.ElasticsearchNode{
+				Sidecars: []v1.Container{{Name: "proxy"}},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "init container named as a sidecar",
+			common: api.ElasticsearchNodeSpec{
+				Sidecars: []v1.Container{{Name: "setup"}},
+			},
+			node: api.ElasticsearchNode{
+				InitContainers: []v1.Container{{Name: "setup"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			node := test.node
+			node.Roles = []api.ElasticsearchNodeRole{"data"}
+			node.NodeCount = 1
+
+			esCR := &api.Elasticsearch{
+				ObjectMeta: metav1.ObjectMeta{Name: "elasticsearch"},
+				Spec: api.ElasticsearchSpec{
+					Spec:  test.common,
+					Nodes: []api.ElasticsearchNode{node},
+				},
+			}
+
+			err := validatePodExtensions(esCR)
+			if test.wantErr && err == nil {
+				t.Errorf("Expected pod extensions to be invalid")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("Expected pod extensions to be valid, got %s", err)
+			}
+		})
+	}
+}
+
 func TestNoTolerations(t *testing.T) {
 	commonTolerations := []v1.Toleration{}
 
@@ -0,0 +1,125 @@
+package kibana
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	kibana "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/horizontalpodautoscaler"
+	"github.com/openshift/elasticsearch-operator/internal/utils"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultTargetCPUUtilizationPercentage int32 = 80
+
+// createOrUpdateKibanaAutoscaler maintains the horizontal pod autoscaler of the Kibana deployment
+// and removes it when autoscaling is disabled
+func (clusterRequest *KibanaRequest) createOrUpdateKibanaAutoscaler() error {
+	cluster := clusterRequest.cluster
+
+	if cluster.Spec.Autoscaling == nil {
+		return clusterRequest.deleteOwnedKibanaAutoscaler()
+	}
+
+	hpa := newKibanaAutoscaler(cluster)
+	utils.AddOwnerRefToObject(hpa, getOwnerRef(cluster))
+
+	err := horizontalpodautoscaler.CreateOrUpdate(context.TODO(), clusterRequest.client, hpa, horizontalpodautoscaler.Equal, horizontalpodautoscaler.Mutate)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to create or update kibana horizontalpodautoscaler",
+			"cluster", cluster.Name,
+			"namespace", cluster.Namespace,
+		)
+	}
+
+	return nil
+}
+
+// deleteOwnedKibanaAutoscaler removes the horizontal pod autoscaler of the Kibana deployment.
+// Autoscalers not owned by Kibana, e.g. created by hand, are left alone.
+func (clusterRequest *KibanaRequest) deleteOwnedKibanaAutoscaler() error {
+	key := client.ObjectKey{Name: "kibana", Namespace: clusterRequest.cluster.Namespace}
+
+	hpa, err := horizontalpodautoscaler.Get(context.TODO(), clusterRequest.client, key)
+	if err != nil {
+		if apierrors.IsNotFound(kverrors.Root(err)) {
+			return nil
+		}
+		return err
+	}
+	if !utils.HasOwnerRef(hpa, getOwnerRef(clusterRequest.cluster)) {
+		return nil
+	}
+
+	return horizontalpodautoscaler.Delete(context.TODO(), clusterRequest.client, key)
+}
+
+func newKibanaAutoscaler(cluster *kibana.Kibana) *autoscalingv2.HorizontalPodAutoscaler {
+	spec := cluster.Spec.Autoscaling
+
+	labels := map[string]string{
+		"logging-infra": "support",
+	}
+
+	minReplicas := autoscalingMinReplicas(spec)
+	builder := horizontalpodautoscaler.New("kibana", cluster.Namespace, labels).
+		WithScaleTarget("kibana").
+		WithReplicas(&minReplicas, spec.MaxReplicas)
+
+	targetCPU := spec.TargetCPUUtilizationPercentage
+	if targetCPU == nil && spec.TargetMemoryUtilizationPercentage == nil {
+		defaultTarget := defaultTargetCPUUtilizationPercentage
+		targetCPU = &defaultTarget
+	}
+	if targetCPU != nil {
+		builder.WithResourceUtilization(corev1.ResourceCPU, *targetCPU)
+	}
+	if spec.TargetMemoryUtilizationPercentage != nil {
+		builder.WithResourceUtilization(corev1.ResourceMemory, *spec.TargetMemoryUtilizationPercentage)
+	}
+
+	return builder.Build()
+}
+
+func autoscalingMinReplicas(spec *kibana.KibanaAutoscalingSpec) int32 {
+	if spec.MinReplicas == nil {
+		return 1
+	}
+	return *spec.MinReplicas
+}
+
+// minReplicas returns the lowest number of Kibana pods of the spec, the replicas new
+// deployments start with while autoscaling is enabled
+func (clusterRequest *KibanaRequest) minReplicas() int32 {
+	if spec := clusterRequest.cluster.Spec.Autoscaling; spec != nil {
+		return autoscalingMinReplicas(spec)
+	}
+	return clusterRequest.cluster.Spec.Replicas
+}
+
+// getAutoscalingStatus returns the scale of Kibana observed by the autoscaler. The previous
+// status is kept when the autoscaler cannot be read.
+func (clusterRequest *KibanaRequest) getAutoscalingStatus() *kibana.KibanaAutoscalingStatus {
+	if clusterRequest.cluster.Spec.Autoscaling == nil {
+		return nil
+	}
+
+	key := client.ObjectKey{Name: "kibana", Namespace: clusterRequest.cluster.Namespace}
+	hpa, err := horizontalpodautoscaler.Get(context.TODO(), clusterRequest.client, key)
+	if err != nil {
+		if apierrors.IsNotFound(kverrors.Root(err)) {
+			return nil
+		}
+		clusterRequest.log.Error(err, "Failed to get the kibana horizontalpodautoscaler status")
+		return clusterRequest.cluster.Status.Autoscaling
+	}
+
+	return &kibana.KibanaAutoscalingStatus{
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+		LastScaleTime:   hpa.Status.LastScaleTime,
+	}
+}
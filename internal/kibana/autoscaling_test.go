@@ -0,0 +1,189 @@
+package kibana
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+
+	apps "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestCreateOrUpdateKibanaAutoscaler(t *testing.T) {
+	key := types.NamespacedName{Name: "kibana", Namespace: "openshift-logging"}
+	cluster := &loggingv1.Kibana{
+		ObjectMeta: metav1.ObjectMeta{Name: "kibana", Namespace: "openshift-logging", UID: "kibana-uid"},
+		Spec:       loggingv1.KibanaSpec{Replicas: 2},
+	}
+	utilization := func(name corev1.ResourceName, percentage int32) autoscalingv2.MetricSpec {
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: name,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: pointer.Int32(percentage),
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		desc            string
+		spec            *loggingv1.KibanaAutoscalingSpec
+		existing        []client.Object
+		wantMinReplicas int32
+		wantMaxReplicas int32
+		wantMetrics     []autoscalingv2.MetricSpec
+		wantReplicas    int32
+		wantKept        bool
+	}{
+		{
+			desc: "autoscaling disabled",
+			existing: []client.Object{
+				&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{
+					Name:            "kibana",
+					Namespace:       "openshift-logging",
+					OwnerReferences: []metav1.OwnerReference{getOwnerRef(cluster)},
+				}},
+			},
+			wantReplicas: 2,
+		},
+		{
+			desc: "autoscaling disabled keeps autoscaler not owned",
+			existing: []client.Object{
+				&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: "kibana", Namespace: "openshift-logging"}},
+			},
+			wantReplicas: 2,
+			wantKept:     true,
+		},
+		{
+			desc:            "default cpu target",
+			spec:            &loggingv1.KibanaAutoscalingSpec{MaxReplicas: 5},
+			wantMinReplicas: 1,
+			wantMaxReplicas: 5,
+			wantMetrics:     []autoscalingv2.MetricSpec{utilization(corev1.ResourceCPU, 80)},
+			wantReplicas:    1,
+		},
+		{
+			desc: "memory target",
+			spec: &loggingv1.KibanaAutoscalingSpec{
+				MinReplicas:                       pointer.Int32(2),
+				MaxReplicas:                       6,
+				TargetMemoryUtilizationPercentage: pointer.Int32(75),
+			},
+			wantMinReplicas: 2,
+			wantMaxReplicas: 6,
+			wantMetrics:     []autoscalingv2.MetricSpec{utilization(corev1.ResourceMemory, 75)},
+			wantReplicas:    2,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			k8sClient := fake.NewClientBuilder().WithObjects(test.existing...).Build()
+			cluster := cluster.DeepCopy()
+			cluster.Spec.Autoscaling = test.spec
+			clusterRequest := &KibanaRequest{
+				client:  k8sClient,
+				cluster: cluster,
+				log:     log.Log,
+			}
+
+			if replicas := clusterRequest.minReplicas(); replicas != test.wantReplicas {
+				t.Errorf("got deployment replicas %d, want %d", replicas, test.wantReplicas)
+			}
+
+			if err := clusterRequest.createOrUpdateKibanaAutoscaler(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			err := k8sClient.Get(context.TODO(), key, hpa)
+			if test.spec == nil {
+				if test.wantKept && err != nil {
+					t.Errorf("expected the autoscaler to be kept, got %v", err)
+				}
+				if !test.wantKept && !apierrors.IsNotFound(err) {
+					t.Errorf("expected the autoscaler to be removed, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected the autoscaler to be created, got %v", err)
+			}
+
+			if hpa.Spec.ScaleTargetRef.Kind != "Deployment" || hpa.Spec.ScaleTargetRef.Name != "kibana" {
+				t.Errorf("unexpected scale target %v", hpa.Spec.ScaleTargetRef)
+			}
+			if *hpa.Spec.MinReplicas != test.wantMinReplicas || hpa.Spec.MaxReplicas != test.wantMaxReplicas {
+				t.Errorf("got replicas %d-%d, want %d-%d", *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas, test.wantMinReplicas, test.wantMaxReplicas)
+			}
+			if diff := cmp.Diff(test.wantMetrics, hpa.Spec.Metrics); diff != "" {
+				t.Errorf("metrics mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeploymentReplicasOwnedByAutoscaler(t *testing.T) {
+	newDeployment := func(replicas int32) *apps.Deployment {
+		return NewDeployment("kibana", "openshift-logging", "kibana", "kibana", replicas, corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "kibana", Image: "kibana"}},
+		})
+	}
+
+	current := newDeployment(4)
+	desired := newDeployment(2)
+
+	if compareDeployments(current, desired) {
+		t.Error("expected the deployments with different replicas to differ")
+	}
+	if !compareDeploymentsIgnoringReplicas(current, desired) {
+		t.Error("expected the deployments to be equal when ignoring the replicas")
+	}
+
+	desired.Spec.Template.Spec.Containers[0].Image = "kibana:new"
+	mutateDeploymentIgnoringReplicas(current, desired)
+	if *current.Spec.Replicas != 4 {
+		t.Errorf("expected the replicas of the autoscaler to be kept, got %d", *current.Spec.Replicas)
+	}
+	if current.Spec.Template.Spec.Containers[0].Image != "kibana:new" {
+		t.Errorf("expected the pod template to be updated, got image %q", current.Spec.Template.Spec.Containers[0].Image)
+	}
+	if *desired.Spec.Replicas != 2 {
+		t.Errorf("expected the desired deployment not to be modified")
+	}
+}
+
+func TestGetAutoscalingStatusKeepsPreviousOnError(t *testing.T) {
+	previous := &loggingv1.KibanaAutoscalingStatus{CurrentReplicas: 3, DesiredReplicas: 4}
+	clusterRequest := &KibanaRequest{
+		// the autoscaler kind is not registered, so reading it fails
+		client: fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build(),
+		cluster: &loggingv1.Kibana{
+			ObjectMeta: metav1.ObjectMeta{Name: "kibana", Namespace: "openshift-logging"},
+			Spec: loggingv1.KibanaSpec{
+				Autoscaling: &loggingv1.KibanaAutoscalingSpec{MaxReplicas: 5},
+			},
+			Status: loggingv1.KibanaStatus{Autoscaling: previous},
+		},
+		log: log.Log,
+	}
+
+	if got := clusterRequest.getAutoscalingStatus(); got != previous {
+		t.Errorf("expected the previous status to be kept, got %v", got)
+	}
+}
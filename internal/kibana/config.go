@@ -0,0 +1,150 @@
+package kibana
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	"github.com/openshift/elasticsearch-operator/internal/constants"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/configmap"
+	"github.com/openshift/elasticsearch-operator/internal/utils"
+	"github.com/openshift/elasticsearch-operator/internal/utils/settings"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kibanaConfigName = "kibana-config"
+	// kibanaConfigFile is the kibana.yml fragment of the settings of the spec
+	kibanaConfigFile = "kibana.yml"
+	// kibanaConfigMountDir is where the fragment is mounted into the Kibana container
+	kibanaConfigMountDir = "/etc/kibana/config"
+	// kibanaDefaultConfig is the kibana.yml of the image, which stays the base of the fragment
+	kibanaDefaultConfig = "/usr/share/kibana/config/kibana.yml"
+)
+
+// kibanaConfigHashName is the pod template annotation which rolls out Kibana on config changes
+var kibanaConfigHashName = constants.SecretHashPrefix + kibanaConfigName
+
+// configPolicy protects the kibana.yml settings owned by the operator,
+// which cannot be overridden through the config of the custom resource
+var configPolicy = settings.Policy{
+	Kind: "kibana.yml setting",
+	Protected: []string{
+		"elasticsearch.hosts",
+		"elasticsearch.url",
+		"elasticsearch.username",
+		"elasticsearch.password",
+		"elasticsearch.ssl",
+		"elasticsearch.requestHeadersWhitelist",
+		"server.host",
+		"server.port",
+		"server.ssl",
+		"opendistro_security",
+		"xpack.security",
+		"path",
+		"pid",
+	},
+}
+
+// renderConfig returns the kibana.yml fragment of the settings of the spec,
+// empty when none of them can be applied
+func renderConfig(config map[string]string) (string, error) {
+	var b strings.Builder
+	if err := configPolicy.Render(&b, config); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// createOrUpdateKibanaConfig maintains the config map of the settings of the spec
+// and removes it when the spec has none
+func (clusterRequest *KibanaRequest) createOrUpdateKibanaConfig() error {
+	key := client.ObjectKey{Name: kibanaConfigName, Namespace: clusterRequest.cluster.Namespace}
+
+	kibanaYml, err := renderConfig(appliedConfig(clusterRequest.cluster.Spec.Config))
+	if err != nil {
+		return kverrors.Wrap(err, "failed to render kibana config",
+			"cluster", clusterRequest.cluster.Name,
+			"namespace", clusterRequest.cluster.Namespace,
+		)
+	}
+
+	if kibanaYml == "" {
+		err := configmap.Delete(context.TODO(), clusterRequest.client, key)
+		if err != nil && !apierrors.IsNotFound(kverrors.Root(err)) {
+			return kverrors.Wrap(err, "failed to delete kibana config map",
+				"cluster", clusterRequest.cluster.Name,
+				"namespace", clusterRequest.cluster.Namespace,
+			)
+		}
+		return nil
+	}
+
+	cm := configmap.New(kibanaConfigName, clusterRequest.cluster.Namespace, map[string]string{
+		"component":     "kibana",
+		"logging-infra": "kibana",
+		"provider":      "openshift",
+	}, map[string]string{kibanaConfigFile: kibanaYml})
+	utils.AddOwnerRefToObject(cm, getOwnerRef(clusterRequest.cluster))
+
+	if _, err := configmap.CreateOrUpdate(context.TODO(), clusterRequest.client, cm, configmap.DataEqual, configmap.MutateDataOnly); err != nil {
+		return kverrors.Wrap(err, "failed to create or update kibana config map",
+			"cluster", clusterRequest.cluster.Name,
+			"namespace", clusterRequest.cluster.Namespace,
+		)
+	}
+
+	return nil
+}
+
+// appliedConfig returns the settings of the spec which are rendered into kibana.yml.
+// Invalid and protected settings are skipped, they are rejected by the config validation.
+func appliedConfig(config map[string]string) map[string]string {
+	applied := map[string]string{}
+	for key, value := range config {
+		if !configPolicy.IsProtected(key) && settings.KeyRegexp.MatchString(key) {
+			applied[key] = value
+		}
+	}
+
+	return applied
+}
+
+// configHash returns a hash of the applied settings which changes whenever a setting is added, removed or changed
+func configHash(config map[string]string) string {
+	return settings.Hash(appliedConfig(config))
+}
+
+// withConfig mounts the kibana.yml fragment into the Kibana container. Kibana merges
+// the files of repeated --config flags, the default file is passed first as any
+// --config flag replaces it.
+func withConfig(podSpec *corev1.PodSpec) {
+	for i, container := range podSpec.Containers {
+		if container.Name != "kibana" {
+			continue
+		}
+
+		podSpec.Containers[i].Args = []string{
+			"--config", kibanaDefaultConfig,
+			"--config", path.Join(kibanaConfigMountDir, kibanaConfigFile),
+		}
+		podSpec.Containers[i].VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      kibanaConfigName,
+			ReadOnly:  true,
+			MountPath: kibanaConfigMountDir,
+		})
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: kibanaConfigName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: kibanaConfigName},
+			},
+		},
+	})
+}
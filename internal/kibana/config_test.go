@@ -0,0 +1,148 @@
+package kibana
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kibana "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		desc    string
+		config  map[string]string
+		wantErr bool
+	}{
+		{
+			desc: "user settings",
+			config: map[string]string{
+				"elasticsearch.requestTimeout":  "60000",
+				"server.defaultRoute":           "/app/discover",
+				"map.includeElasticMapsService": "false",
+			},
+		},
+		{
+			desc:    "elasticsearch hosts",
+			config:  map[string]string{"elasticsearch.hosts": `["https://other:9200"]`},
+			wantErr: true,
+		},
+		{
+			desc:    "nested security setting",
+			config:  map[string]string{"opendistro_security.multitenancy.enabled": "false"},
+			wantErr: true,
+		},
+		{
+			desc:    "malformed setting name",
+			config:  map[string]string{"server.name: kibana\nserver.port": "8080"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			err := configPolicy.Validate(test.config)
+			if test.wantErr && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestConfigHash(t *testing.T) {
+	config := map[string]string{
+		"elasticsearch.requestTimeout": "60000",
+		"server.defaultRoute":          "/app/discover",
+		"server.port":                  "8080",
+	}
+
+	hash := configHash(config)
+	if hash == "" {
+		t.Fatalf("expected a hash for the config")
+	}
+	config["server.port"] = "9090"
+	if configHash(config) != hash {
+		t.Errorf("expected protected settings not to change the hash")
+	}
+	config["elasticsearch.requestTimeout"] = "30000"
+	if configHash(config) == hash {
+		t.Errorf("expected a changed setting to change the hash")
+	}
+	if configHash(nil) != "" {
+		t.Errorf("expected no hash without config")
+	}
+}
+
+func TestCreateOrUpdateKibanaConfig(t *testing.T) {
+	key := types.NamespacedName{Name: kibanaConfigName, Namespace: "openshift-logging"}
+	cluster := &kibana.Kibana{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kibana",
+			Namespace: "openshift-logging",
+		},
+		Spec: kibana.KibanaSpec{
+			Config: map[string]string{
+				"elasticsearch.requestTimeout": "60000",
+				"server.port":                  "8080",
+			},
+		},
+	}
+
+	k8sClient := fake.NewFakeClient()
+	clusterRequest := &KibanaRequest{
+		client:  k8sClient,
+		cluster: cluster,
+		log:     log.Log,
+	}
+
+	if err := clusterRequest.createOrUpdateKibanaConfig(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cm := &v1.ConfigMap{}
+	if err := k8sClient.Get(context.TODO(), key, cm); err != nil {
+		t.Fatalf("expected the config map to be created, got %v", err)
+	}
+	want := map[string]string{kibanaConfigFile: "elasticsearch.requestTimeout: 60000\n"}
+	if diff := cmp.Diff(want, cm.Data); diff != "" {
+		t.Errorf("config map data mismatch (-want +got):\n%s", diff)
+	}
+
+	podSpec := newKibanaPodSpec(clusterRequest, "elasticsearch", nil, oauthTimeout, nil, "")
+	wantArgs := []string{"--config", "/usr/share/kibana/config/kibana.yml", "--config", "/etc/kibana/config/kibana.yml"}
+	if diff := cmp.Diff(wantArgs, podSpec.Containers[0].Args); diff != "" {
+		t.Errorf("kibana args mismatch (-want +got):\n%s", diff)
+	}
+	wantMount := v1.VolumeMount{Name: kibanaConfigName, ReadOnly: true, MountPath: "/etc/kibana/config"}
+	if mounts := podSpec.Containers[0].VolumeMounts; mounts[len(mounts)-1] != wantMount {
+		t.Errorf("expected the kibana container to mount the config map, got %v", mounts)
+	}
+	wantVolume := v1.Volume{
+		Name: kibanaConfigName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: kibanaConfigName}},
+		},
+	}
+	if diff := cmp.Diff(wantVolume, podSpec.Volumes[len(podSpec.Volumes)-1]); diff != "" {
+		t.Errorf("config volume mismatch (-want +got):\n%s", diff)
+	}
+
+	cluster.Spec.Config = nil
+	if err := clusterRequest.createOrUpdateKibanaConfig(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := k8sClient.Get(context.TODO(), key, &v1.ConfigMap{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the config map to be deleted without config, got %v", err)
+	}
+}
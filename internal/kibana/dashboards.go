@@ -0,0 +1,316 @@
+package kibana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	kibana "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	"github.com/openshift/elasticsearch-operator/internal/constants"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/configmap"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/deployment"
+	"github.com/openshift/elasticsearch-operator/internal/utils"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	savedObjectsImportURI = "/api/saved_objects/_import?overwrite=true"
+	dashboardsImportURI   = "/api/kibana/dashboards/import?force=true"
+
+	savedObjectsImportBackoff    = 30 * time.Second
+	savedObjectsImportMaxBackoff = 30 * time.Minute
+)
+
+type savedObjectsImportResponse struct {
+	Success bool                     `json:"success"`
+	Errors  []savedObjectImportError `json:"errors,omitempty"`
+}
+
+type savedObjectImportError struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message,omitempty"`
+	} `json:"error"`
+}
+
+type dashboardsImportResponse struct {
+	Objects []savedObject `json:"objects"`
+}
+
+// importSavedObjectConfigMaps imports the saved objects of the configmaps labelled with
+// logging.openshift.io/kibana-saved-objects=true through the API of a ready Kibana pod once the
+// rollout of the deployment completes. The objects of a configmap are imported again when its
+// data changes or the Kibana pods are replaced or restarted, as they may run on a reset or
+// migrated .kibana index. Failed imports are retried with an exponential backoff. The .ndjson
+// keys hold saved objects exports and the .json keys dashboards exports.
+func (clusterRequest *KibanaRequest) importSavedObjectConfigMaps() error {
+	cluster := clusterRequest.cluster
+
+	configMaps, err := configmap.List(context.TODO(), clusterRequest.client, cluster.Namespace, map[string]string{
+		constants.KibanaSavedObjectsLabel: "true",
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(configMaps, func(i, j int) bool { return configMaps[i].Name < configMaps[j].Name })
+
+	previous := map[string]kibana.KibanaSavedObjectsStatus{}
+	for _, s := range cluster.Status.SavedObjects {
+		previous[s.ConfigMap] = s
+	}
+
+	host := ""
+	podsHash := ""
+	if len(configMaps) > 0 {
+		key := client.ObjectKey{Name: "kibana", Namespace: cluster.Namespace}
+		dpl, err := deployment.Get(context.TODO(), clusterRequest.client, key)
+		if err != nil {
+			return err
+		}
+		if isRolledOut(dpl) {
+			pods, err := clusterRequest.readyKibanaPods()
+			if err != nil {
+				return err
+			}
+			if len(pods) > 0 {
+				host = kibanaPodAPIHost(pods[0])
+				if podsHash, err = kibanaPodsHash(pods); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	now := metav1.Now()
+	statuses := []kibana.KibanaSavedObjectsStatus{}
+	for _, cm := range configMaps {
+		hash, err := savedObjectsHash(cm.Data)
+		if err != nil {
+			return err
+		}
+
+		current, found := previous[cm.Name]
+		if !found {
+			current = kibana.KibanaSavedObjectsStatus{ConfigMap: cm.Name}
+		}
+		sameImport := found && current.Hash == hash && current.PodsHash == podsHash
+		// the objects wait for kibana to be rolled out, are already imported or wait for the
+		// backoff of the failed import to expire
+		if host == "" || (sameImport && (current.Imported || !importRetryDue(current, now.Time))) {
+			statuses = append(statuses, current)
+			continue
+		}
+
+		status := kibana.KibanaSavedObjectsStatus{
+			ConfigMap:       cm.Name,
+			Hash:            hash,
+			PodsHash:        podsHash,
+			LastImportTime:  current.LastImportTime,
+			LastAttemptTime: &now,
+		}
+		if msg := clusterRequest.importSavedObjects(host, cm.Data); msg != "" {
+			clusterRequest.log.Info("Failed to import kibana saved objects", "configmap", cm.Name, "message", msg)
+			status.Message = msg
+			status.FailedAttempts = 1
+			if sameImport {
+				status.FailedAttempts = current.FailedAttempts + 1
+			}
+		} else {
+			status.Imported = true
+			status.LastImportTime = &now
+		}
+		statuses = append(statuses, status)
+	}
+
+	clusterRequest.savedObjects = statuses
+	return nil
+}
+
+// importRetryDue returns true once the backoff of the failed imports of the saved objects
+// expired. The backoff doubles with every failed attempt up to the maximum backoff.
+func importRetryDue(status kibana.KibanaSavedObjectsStatus, now time.Time) bool {
+	if status.LastAttemptTime == nil || status.FailedAttempts == 0 {
+		return true
+	}
+
+	backoff := savedObjectsImportBackoff
+	for i := int32(1); i < status.FailedAttempts && backoff < savedObjectsImportMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > savedObjectsImportMaxBackoff {
+		backoff = savedObjectsImportMaxBackoff
+	}
+
+	return !now.Before(status.LastAttemptTime.Add(backoff))
+}
+
+// kibanaPodsHash returns the hash of the UIDs and the restarts of the kibana container of the pods
+func kibanaPodsHash(pods []v1.Pod) (string, error) {
+	ids := make([]string, 0, len(pods))
+	for _, p := range pods {
+		var restarts int32
+		for _, status := range p.Status.ContainerStatuses {
+			if status.Name == "kibana" {
+				restarts = status.RestartCount
+			}
+		}
+		ids = append(ids, fmt.Sprintf("%s/%d", p.UID, restarts))
+	}
+	sort.Strings(ids)
+
+	return utils.CalculateMD5Hash(strings.Join(ids, ","))
+}
+
+// importSavedObjects imports the saved objects of each key of the data and returns the errors
+// of the import, an empty message if all the objects are imported
+func (clusterRequest *KibanaRequest) importSavedObjects(host string, data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var messages []string
+	for _, key := range keys {
+		var failures []string
+		var err error
+		switch filepath.Ext(key) {
+		case ".ndjson":
+			failures, err = clusterRequest.importSavedObjectsExport(host, key, data[key])
+		case ".json":
+			failures, err = clusterRequest.importDashboardsExport(host, data[key])
+		default:
+			err = kverrors.New("unsupported key, expected a .ndjson or .json extension")
+		}
+
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("%s: %s", key, err))
+		} else if len(failures) > 0 {
+			messages = append(messages, fmt.Sprintf("%s: failed to import %s", key, strings.Join(failures, ", ")))
+		}
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// importSavedObjectsExport imports the NDJSON file of a saved objects export, overwriting the existing objects
+func (clusterRequest *KibanaRequest) importSavedObjectsExport(host, filename, content string) ([]string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, kverrors.Wrap(err, "failed to create saved objects import request")
+	}
+	if _, err := part.Write([]byte(content)); err != nil {
+		return nil, kverrors.Wrap(err, "failed to create saved objects import request")
+	}
+	if err := writer.Close(); err != nil {
+		return nil, kverrors.Wrap(err, "failed to create saved objects import request")
+	}
+
+	payload := &KibanaAPIRequest{
+		Method:      http.MethodPost,
+		URI:         savedObjectsImportURI,
+		ContentType: writer.FormDataContentType(),
+		RequestBody: body.String(),
+		Tenant:      globalTenant,
+	}
+	clusterRequest.fnSendKibanaRequest(clusterRequest.log, host, payload)
+	if err := kibanaAPIError(payload); err != nil {
+		return nil, err
+	}
+
+	res := savedObjectsImportResponse{}
+	if err := json.Unmarshal([]byte(payload.RawResponseBody), &res); err != nil {
+		return nil, kverrors.Wrap(err, "failed to parse saved objects import response")
+	}
+
+	var failures []string
+	for _, e := range res.Errors {
+		reason := e.Error.Type
+		if e.Error.Message != "" {
+			reason = fmt.Sprintf("%s %s", reason, e.Error.Message)
+		}
+		failures = append(failures, fmt.Sprintf("%s/%s (%s)", e.Type, e.ID, reason))
+	}
+	if !res.Success && len(failures) == 0 {
+		failures = append(failures, "all objects")
+	}
+
+	return failures, nil
+}
+
+// importDashboardsExport imports the JSON file of a dashboards export, overwriting the existing objects
+func (clusterRequest *KibanaRequest) importDashboardsExport(host, content string) ([]string, error) {
+	payload := &KibanaAPIRequest{
+		Method:      http.MethodPost,
+		URI:         dashboardsImportURI,
+		RequestBody: content,
+		Tenant:      globalTenant,
+	}
+	clusterRequest.fnSendKibanaRequest(clusterRequest.log, host, payload)
+	if err := kibanaAPIError(payload); err != nil {
+		return nil, err
+	}
+
+	res := dashboardsImportResponse{}
+	if err := json.Unmarshal([]byte(payload.RawResponseBody), &res); err != nil {
+		return nil, kverrors.Wrap(err, "failed to parse dashboards import response")
+	}
+
+	var failures []string
+	for _, obj := range res.Objects {
+		if obj.Error != nil {
+			failures = append(failures, fmt.Sprintf("%s/%s (%s)", obj.Type, obj.ID, obj.Error.Message))
+		}
+	}
+
+	return failures, nil
+}
+
+// getSavedObjectsStatus returns the import status of the saved objects configmaps of the last
+// reconciliation or the current one when they were not reconciled
+func (clusterRequest *KibanaRequest) getSavedObjectsStatus() []kibana.KibanaSavedObjectsStatus {
+	statuses := clusterRequest.cluster.Status.SavedObjects
+	if clusterRequest.savedObjects != nil {
+		statuses = clusterRequest.savedObjects
+	}
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	copied := make([]kibana.KibanaSavedObjectsStatus, 0, len(statuses))
+	for _, s := range statuses {
+		copied = append(copied, *s.DeepCopy())
+	}
+	return copied
+}
+
+// savedObjectsHash returns the hash of the data of a saved objects configmap
+func savedObjectsHash(data map[string]string) (string, error) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		b.WriteString(key)
+		b.WriteString(data[key])
+	}
+
+	return utils.CalculateMD5Hash(b.String())
+}
@@ -0,0 +1,285 @@
+package kibana
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	"github.com/openshift/elasticsearch-operator/internal/constants"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestImportSavedObjectConfigMaps(t *testing.T) {
+	dpl := &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "kibana", Namespace: "openshift-logging", Generation: 3},
+		Spec:       apps.DeploymentSpec{Replicas: pointer.Int32(1)},
+		Status: apps.DeploymentStatus{
+			ObservedGeneration: 3,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  1,
+		},
+	}
+	kibanaPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kibana-1",
+			Namespace: "openshift-logging",
+			Labels:    map[string]string{"component": "kibana"},
+			UID:       "kibana-1-uid",
+		},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			PodIP:             "10.0.0.1",
+			ContainerStatuses: []v1.ContainerStatus{{Name: "kibana", Ready: true}},
+		},
+	}
+	data := map[string]string{
+		"audit.ndjson": `{"type":"dashboard","id":"audit","attributes":{"title":"Audit"}}`,
+		"infra.json":   `{"version":"6.8.1","objects":[{"type":"visualization","id":"infra","attributes":{"title":"Infra"}}]}`,
+	}
+	dashboards := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dashboards",
+			Namespace: "openshift-logging",
+			Labels:    map[string]string{constants.KibanaSavedObjectsLabel: "true"},
+		},
+		Data: map[string]string{
+			"audit.ndjson": data["audit.ndjson"],
+			"infra.json":   data["infra.json"],
+		},
+	}
+	unlabelled := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "unlabelled", Namespace: "openshift-logging"},
+		Data:       map[string]string{"other.json": "{}"},
+	}
+	hash, err := savedObjectsHash(dashboards.Data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	podsHash, err := kibanaPodsHash([]v1.Pod{*kibanaPod})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	justNow := metav1.NewTime(time.Now().Add(-time.Second))
+	longAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		desc          string
+		rolledOut     bool
+		current       []loggingv1.KibanaSavedObjectsStatus
+		importErrors  bool
+		wantRequests  []string
+		wantImported  bool
+		wantMessage   string
+		wantFailed    int32
+		wantUntouched bool
+	}{
+		{
+			desc:         "rollout in progress",
+			wantRequests: nil,
+		},
+		{
+			desc:      "saved objects to import",
+			rolledOut: true,
+			wantRequests: []string{
+				"POST 10.0.0.1:5601/api/saved_objects/_import?overwrite=true",
+				"POST 10.0.0.1:5601/api/kibana/dashboards/import?force=true",
+			},
+			wantImported: true,
+		},
+		{
+			desc:      "saved objects already imported",
+			rolledOut: true,
+			current: []loggingv1.KibanaSavedObjectsStatus{
+				{ConfigMap: "dashboards", Hash: hash, PodsHash: podsHash, Imported: true},
+				{ConfigMap: "removed", Hash: hash, PodsHash: podsHash, Imported: true},
+			},
+			wantRequests:  nil,
+			wantImported:  true,
+			wantUntouched: true,
+		},
+		{
+			desc:      "saved objects imported into replaced pods",
+			rolledOut: true,
+			current: []loggingv1.KibanaSavedObjectsStatus{
+				{ConfigMap: "dashboards", Hash: hash, PodsHash: "replaced", Imported: true},
+			},
+			wantRequests: []string{
+				"POST 10.0.0.1:5601/api/saved_objects/_import?overwrite=true",
+				"POST 10.0.0.1:5601/api/kibana/dashboards/import?force=true",
+			},
+			wantImported: true,
+		},
+		{
+			desc:         "saved objects failing to import",
+			rolledOut:    true,
+			importErrors: true,
+			wantRequests: []string{
+				"POST 10.0.0.1:5601/api/saved_objects/_import?overwrite=true",
+				"POST 10.0.0.1:5601/api/kibana/dashboards/import?force=true",
+			},
+			wantMessage: "audit.ndjson: failed to import dashboard/audit (missing_references); " +
+				"infra.json: failed to import visualization/infra (bad request)",
+			wantFailed: 1,
+		},
+		{
+			desc:      "failed import within the backoff",
+			rolledOut: true,
+			current: []loggingv1.KibanaSavedObjectsStatus{
+				{ConfigMap: "dashboards", Hash: hash, PodsHash: podsHash, LastAttemptTime: &justNow, FailedAttempts: 2, Message: "failed"},
+			},
+			wantRequests:  nil,
+			wantUntouched: true,
+		},
+		{
+			desc:         "failed import after the backoff",
+			rolledOut:    true,
+			importErrors: true,
+			current: []loggingv1.KibanaSavedObjectsStatus{
+				{ConfigMap: "dashboards", Hash: hash, PodsHash: podsHash, LastAttemptTime: &longAgo, FailedAttempts: 2, Message: "failed"},
+			},
+			wantRequests: []string{
+				"POST 10.0.0.1:5601/api/saved_objects/_import?overwrite=true",
+				"POST 10.0.0.1:5601/api/kibana/dashboards/import?force=true",
+			},
+			wantMessage: "audit.ndjson: failed to import dashboard/audit (missing_references); " +
+				"infra.json: failed to import visualization/infra (bad request)",
+			wantFailed: 3,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			d := dpl.DeepCopy()
+			if !test.rolledOut {
+				d.Status.UpdatedReplicas = 0
+			}
+
+			var requests []string
+			clusterRequest := &KibanaRequest{
+				client: fake.NewFakeClient(d, kibanaPod, dashboards, unlabelled),
+				cluster: &loggingv1.Kibana{
+					ObjectMeta: metav1.ObjectMeta{Name: "kibana", Namespace: "openshift-logging"},
+					Status:     loggingv1.KibanaStatus{SavedObjects: test.current},
+				},
+				log: log.Log,
+				fnSendKibanaRequest: func(_ logr.Logger, host string, payload *KibanaAPIRequest) {
+					requests = append(requests, payload.Method+" "+host+payload.URI)
+					if payload.Tenant != globalTenant {
+						t.Errorf("expected the request to target the global tenant, got %q", payload.Tenant)
+					}
+					payload.StatusCode = http.StatusOK
+					switch payload.URI {
+					case savedObjectsImportURI:
+						if !strings.HasPrefix(payload.ContentType, "multipart/form-data") {
+							t.Errorf("unexpected content type %q", payload.ContentType)
+						}
+						if !strings.Contains(payload.RequestBody, data["audit.ndjson"]) {
+							t.Errorf("expected the export in the request, got %q", payload.RequestBody)
+						}
+						payload.RawResponseBody = `{"success":true,"successCount":1}`
+						if test.importErrors {
+							payload.RawResponseBody = `{"success":false,"successCount":0,"errors":[{"type":"dashboard","id":"audit","error":{"type":"missing_references"}}]}`
+						}
+					case dashboardsImportURI:
+						if payload.RequestBody != data["infra.json"] {
+							t.Errorf("expected the export as request, got %q", payload.RequestBody)
+						}
+						payload.RawResponseBody = `{"objects":[{"type":"visualization","id":"infra"}]}`
+						if test.importErrors {
+							payload.RawResponseBody = `{"objects":[{"type":"visualization","id":"infra","error":{"statusCode":400,"message":"bad request"}}]}`
+						}
+					}
+				},
+			}
+
+			if err := clusterRequest.importSavedObjectConfigMaps(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.wantRequests, requests); diff != "" {
+				t.Errorf("requests mismatch (-want +got):\n%s", diff)
+			}
+
+			statuses := clusterRequest.getSavedObjectsStatus()
+			if len(statuses) != 1 || statuses[0].ConfigMap != "dashboards" {
+				t.Fatalf("expected the status of the dashboards configmap only, got %v", statuses)
+			}
+			status := statuses[0]
+			if test.wantUntouched {
+				if diff := cmp.Diff(test.current[0], status); diff != "" {
+					t.Errorf("status mismatch (-want +got):\n%s", diff)
+				}
+				return
+			}
+			if status.Imported != test.wantImported || status.Message != test.wantMessage {
+				t.Errorf("got imported %t with message %q, want %t with %q", status.Imported, status.Message, test.wantImported, test.wantMessage)
+			}
+			if test.rolledOut && (status.LastAttemptTime == nil || status.Hash != hash || status.PodsHash != podsHash) {
+				t.Errorf("expected an attempt to import the current data into the current pods, got %v", status)
+			}
+			if test.wantImported && status.LastImportTime == nil {
+				t.Errorf("expected the import time to be set, got %v", status)
+			}
+			if status.FailedAttempts != test.wantFailed {
+				t.Errorf("got %d failed attempts, want %d", status.FailedAttempts, test.wantFailed)
+			}
+		})
+	}
+}
+
+func TestImportRetryDue(t *testing.T) {
+	now := time.Now()
+	attempt := func(ago time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-ago))
+		return &t
+	}
+
+	tests := []struct {
+		desc   string
+		status loggingv1.KibanaSavedObjectsStatus
+		want   bool
+	}{
+		{
+			desc: "never attempted",
+			want: true,
+		},
+		{
+			desc:   "first failure within the backoff",
+			status: loggingv1.KibanaSavedObjectsStatus{LastAttemptTime: attempt(10 * time.Second), FailedAttempts: 1},
+		},
+		{
+			desc:   "first failure after the backoff",
+			status: loggingv1.KibanaSavedObjectsStatus{LastAttemptTime: attempt(30 * time.Second), FailedAttempts: 1},
+			want:   true,
+		},
+		{
+			desc:   "backoff doubled",
+			status: loggingv1.KibanaSavedObjectsStatus{LastAttemptTime: attempt(90 * time.Second), FailedAttempts: 3},
+		},
+		{
+			desc:   "backoff capped",
+			status: loggingv1.KibanaSavedObjectsStatus{LastAttemptTime: attempt(30 * time.Minute), FailedAttempts: 100},
+			want:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			if got := importRetryDue(test.status, now); got != test.want {
+				t.Errorf("got retry due %t, want %t", got, test.want)
+			}
+		})
+	}
+}
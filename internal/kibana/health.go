@@ -0,0 +1,136 @@
+package kibana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/pod"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	kibanaStatusURI = "/api/status"
+
+	kibanaStateGreen  = "green"
+	kibanaStateYellow = "yellow"
+	kibanaStateRed    = "red"
+)
+
+// kibanaHealth is the state of Kibana and its plugins reported by its status API
+type kibanaHealth struct {
+	// State is the overall state, green, yellow, red or uninitialized
+	State string
+	// Failures are the messages of the plugins which are not green
+	Failures []string
+	// Error is set when the status API cannot be queried
+	Error error
+}
+
+type statusResponse struct {
+	Status struct {
+		Overall  pluginState   `json:"overall"`
+		Statuses []pluginState `json:"statuses"`
+	} `json:"status"`
+}
+
+type pluginState struct {
+	ID      string `json:"id,omitempty"`
+	State   string `json:"state"`
+	Message string `json:"message,omitempty"`
+}
+
+// stateSeverity orders the states of the Kibana pods, unknown states like uninitialized
+// rank between yellow and red
+var stateSeverity = map[string]int{
+	kibanaStateGreen:  0,
+	kibanaStateYellow: 1,
+	kibanaStateRed:    3,
+}
+
+func severity(state string) int {
+	if s, ok := stateSeverity[state]; ok {
+		return s
+	}
+	return 2
+}
+
+// getKibanaHealth queries the status API of every running Kibana pod, ready or not, and
+// reports the worst state. Pods whose API cannot be queried yet are skipped as long as
+// one pod answers.
+func (clusterRequest *KibanaRequest) getKibanaHealth() *kibanaHealth {
+	pods, err := pod.List(context.TODO(), clusterRequest.client, clusterRequest.cluster.Namespace, map[string]string{
+		"component": "kibana",
+	})
+	if err != nil {
+		return &kibanaHealth{Error: err}
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	var health *kibanaHealth
+	var queryErr error
+	for _, p := range pods {
+		if p.Status.Phase != v1.PodRunning || p.Status.PodIP == "" {
+			continue
+		}
+
+		podHealth := clusterRequest.getKibanaPodHealth(kibanaPodAPIHost(p))
+		if podHealth.Error != nil {
+			if queryErr == nil {
+				queryErr = kverrors.Wrap(podHealth.Error, "failed to query the kibana status", "pod", p.Name)
+			}
+			continue
+		}
+
+		if health == nil {
+			health = &kibanaHealth{State: podHealth.State}
+		} else if severity(podHealth.State) > severity(health.State) {
+			health.State = podHealth.State
+		}
+		for _, failure := range podHealth.Failures {
+			health.Failures = append(health.Failures, fmt.Sprintf("%s: %s", p.Name, failure))
+		}
+	}
+
+	switch {
+	case health != nil:
+		return health
+	case queryErr != nil:
+		return &kibanaHealth{Error: queryErr}
+	default:
+		return &kibanaHealth{Error: kverrors.New("no kibana pod is running")}
+	}
+}
+
+// getKibanaPodHealth queries the status API of a single Kibana pod
+func (clusterRequest *KibanaRequest) getKibanaPodHealth(host string) *kibanaHealth {
+	payload := &KibanaAPIRequest{
+		Method: http.MethodGet,
+		URI:    kibanaStatusURI,
+	}
+	clusterRequest.fnSendKibanaRequest(clusterRequest.log, host, payload)
+	// kibana answers 503 while its overall state is red
+	if payload.Error != nil || (payload.StatusCode != http.StatusOK && payload.StatusCode != http.StatusServiceUnavailable) {
+		return &kibanaHealth{Error: kibanaAPIError(payload)}
+	}
+
+	res := statusResponse{}
+	if err := json.Unmarshal([]byte(payload.RawResponseBody), &res); err != nil {
+		return &kibanaHealth{Error: kverrors.Wrap(err, "failed to parse kibana status response")}
+	}
+	if res.Status.Overall.State == "" {
+		return &kibanaHealth{Error: kverrors.New("kibana status response has no overall state")}
+	}
+
+	health := &kibanaHealth{State: res.Status.Overall.State}
+	for _, plugin := range res.Status.Statuses {
+		if plugin.State != kibanaStateGreen {
+			health.Failures = append(health.Failures, fmt.Sprintf("%s %s: %s", plugin.ID, plugin.State, plugin.Message))
+		}
+	}
+
+	return health
+}
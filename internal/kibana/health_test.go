@@ -0,0 +1,144 @@
+package kibana
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestGetKibanaHealth(t *testing.T) {
+	newPod := func(name, ip string, ready bool) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "openshift-logging",
+				Labels:    map[string]string{"component": "kibana"},
+			},
+			Status: v1.PodStatus{
+				Phase:             v1.PodRunning,
+				PodIP:             ip,
+				ContainerStatuses: []v1.ContainerStatus{{Ready: ready}},
+			},
+		}
+	}
+
+	type response struct {
+		statusCode int
+		body       string
+		err        error
+	}
+
+	green := response{
+		statusCode: http.StatusOK,
+		body:       `{"status":{"overall":{"state":"green"},"statuses":[{"id":"plugin:kibana@6.8.1","state":"green","message":"Ready"}]}}`,
+	}
+	red := response{
+		statusCode: http.StatusServiceUnavailable,
+		body: `{"status":{"overall":{"state":"red"},"statuses":[` +
+			`{"id":"plugin:kibana@6.8.1","state":"green","message":"Ready"},` +
+			`{"id":"plugin:elasticsearch@6.8.1","state":"red","message":"Migration of the .kibana index failed"}]}}`,
+	}
+
+	tests := []struct {
+		desc         string
+		pods         []*v1.Pod
+		responses    map[string]response
+		wantRequests []string
+		wantState    string
+		wantFailures []string
+		wantError    bool
+	}{
+		{
+			desc:      "no running pod",
+			wantError: true,
+		},
+		{
+			desc:         "green",
+			pods:         []*v1.Pod{newPod("kibana-1", "10.0.0.1", true)},
+			responses:    map[string]response{"10.0.0.1:5601": green},
+			wantRequests: []string{"GET 10.0.0.1:5601/api/status"},
+			wantState:    kibanaStateGreen,
+		},
+		{
+			desc:         "red pod not ready yet",
+			pods:         []*v1.Pod{newPod("kibana-1", "10.0.0.1", true), newPod("kibana-2", "10.0.0.2", false)},
+			responses:    map[string]response{"10.0.0.1:5601": green, "10.0.0.2:5601": red},
+			wantRequests: []string{"GET 10.0.0.1:5601/api/status", "GET 10.0.0.2:5601/api/status"},
+			wantState:    kibanaStateRed,
+			wantFailures: []string{"kibana-2: plugin:elasticsearch@6.8.1 red: Migration of the .kibana index failed"},
+		},
+		{
+			desc: "pod not listening yet is skipped",
+			pods: []*v1.Pod{newPod("kibana-1", "10.0.0.1", true), newPod("kibana-2", "10.0.0.2", false)},
+			responses: map[string]response{
+				"10.0.0.1:5601": green,
+				"10.0.0.2:5601": {err: errors.New("connection refused")},
+			},
+			wantRequests: []string{"GET 10.0.0.1:5601/api/status", "GET 10.0.0.2:5601/api/status"},
+			wantState:    kibanaStateGreen,
+		},
+		{
+			desc:      "unexpected response",
+			pods:      []*v1.Pod{newPod("kibana-1", "10.0.0.1", true)},
+			responses: map[string]response{"10.0.0.1:5601": {statusCode: http.StatusUnauthorized, body: `{"statusCode":401}`}},
+			wantError: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			objs := make([]runtime.Object, 0, len(test.pods))
+			for _, p := range test.pods {
+				objs = append(objs, p)
+			}
+
+			var requests []string
+			clusterRequest := &KibanaRequest{
+				client: fake.NewFakeClient(objs...),
+				cluster: &loggingv1.Kibana{
+					ObjectMeta: metav1.ObjectMeta{Name: "kibana", Namespace: "openshift-logging"},
+				},
+				log: log.Log,
+				fnSendKibanaRequest: func(_ logr.Logger, host string, payload *KibanaAPIRequest) {
+					requests = append(requests, payload.Method+" "+host+payload.URI)
+					res := test.responses[host]
+					payload.StatusCode = res.statusCode
+					payload.RawResponseBody = res.body
+					payload.Error = res.err
+				},
+			}
+
+			health := clusterRequest.getKibanaHealth()
+			if test.wantError {
+				if health.Error == nil {
+					t.Errorf("expected an error, got %v", health)
+				}
+				return
+			}
+			if health.Error != nil {
+				t.Fatalf("unexpected error: %s", health.Error)
+			}
+
+			if diff := cmp.Diff(test.wantRequests, requests); diff != "" {
+				t.Errorf("requests mismatch (-want +got):\n%s", diff)
+			}
+			if health.State != test.wantState {
+				t.Errorf("got state %q, want %q", health.State, test.wantState)
+			}
+			if diff := cmp.Diff(test.wantFailures, health.Failures); diff != "" {
+				t.Errorf("failures mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package kibana
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	kibana "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/ingress"
+	"github.com/openshift/elasticsearch-operator/internal/utils"
+	networking "k8s.io/api/networking/v1"
+)
+
+// createOrUpdateKibanaIngress exposes the proxy through an Ingress on Kubernetes
+func (clusterRequest *KibanaRequest) createOrUpdateKibanaIngress() error {
+	cluster := clusterRequest.cluster
+
+	ing := newKibanaIngress(cluster)
+	utils.AddOwnerRefToObject(ing, getOwnerRef(cluster))
+
+	err := ingress.CreateOrUpdate(context.TODO(), clusterRequest.client, ing, ingress.Equal, ingress.Mutate)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to create or update kibana ingress",
+			"cluster", cluster.Name,
+			"namespace", cluster.Namespace,
+		)
+	}
+
+	return nil
+}
+
+func newKibanaIngress(cluster *kibana.Kibana) *networking.Ingress {
+	labels := map[string]string{
+		"component":     "support",
+		"logging-infra": "support",
+		"provider":      "openshift",
+	}
+
+	builder := ingress.New("kibana", cluster.Namespace, labels)
+
+	spec := cluster.Spec.Ingress
+	if spec == nil {
+		return builder.WithServiceBackend("", "kibana", 443).Build()
+	}
+
+	builder.WithAnnotations(spec.Annotations).
+		WithIngressClassName(spec.IngressClassName).
+		WithServiceBackend(spec.Host, "kibana", 443)
+
+	if spec.TLSSecretName != "" {
+		var hosts []string
+		if spec.Host != "" {
+			hosts = []string{spec.Host}
+		}
+		builder.WithTLS(spec.TLSSecretName, hosts...)
+	}
+
+	return builder.Build()
+}
+
+// getIngressURL returns the URL of the host of the Ingress or an empty one when
+// the Ingress matches all hosts
+func (clusterRequest *KibanaRequest) getIngressURL() string {
+	spec := clusterRequest.cluster.Spec.Ingress
+	if spec == nil || spec.Host == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://%s", spec.Host)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/openshift/elasticsearch-operator/test/helpers"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -346,6 +347,22 @@ var _ = Describe("Reconciling", func() {
 				Expect(depl.Spec.Template.Spec.Containers[1].Image).To(Equal(proxySourceImage.Status.Tags[0].Items[0].DockerImageReference))
 			})
 
+			It("should connect to the referenced elasticsearch of another namespace", func() {
+				esClient = helpers.NewFakeElasticsearchClient("elasticsearch-app", "logging-storage", client, helpers.NewFakeElasticsearchChatter(fakeResponses))
+
+				Expect(Reconcile(logger, cluster, client, esClient, proxy, false, metav1.OwnerReference{})).Should(Succeed())
+
+				key := types.NamespacedName{Name: "kibana", Namespace: cluster.GetNamespace()}
+				depl := &appsv1.Deployment{}
+
+				err := client.Get(context.TODO(), key, depl)
+				Expect(err).To(BeNil())
+				Expect(depl.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+					Name:  "ELASTICSEARCH_HOSTS",
+					Value: `["https://elasticsearch-app.logging-storage.svc:9200"]`,
+				}))
+			})
+
 			It("should create a deployment with the local kibana proxy image", func() {
 				client = fake.NewFakeClient(
 					cluster,
@@ -366,6 +383,58 @@ var _ = Describe("Reconciling", func() {
 				Expect(depl.Spec.Template.Spec.Containers[1].Image).To(Equal(fmt.Sprintf("%s@%s", proxyLocalImage.Status.DockerImageRepository, proxyLocalImage.Status.Tags[0].Items[0].Image)))
 			})
 		})
+
+		Context("when running on kubernetes", func() {
+			var k8sCluster *loggingv1.Kibana
+
+			BeforeEach(func() {
+				k8sCluster = cluster.DeepCopy()
+				k8sCluster.Spec.Platform = loggingv1.KibanaPlatformKubernetes
+				k8sCluster.Spec.Ingress = &loggingv1.KibanaIngressSpec{Host: "kibana.example.com"}
+				k8sCluster.Spec.ProxySpec.OAuth = &loggingv1.KibanaOAuthSpec{Image: "quay.io/openshift/origin-oauth-proxy:latest"}
+				k8sCluster.Spec.ProxySpec.BasicAuth = &loggingv1.KibanaBasicAuthSpec{SecretName: "kibana-htpasswd"}
+
+				client = fake.NewFakeClient(
+					k8sCluster,
+					kibanaSecret,
+					kibanaProxySecret,
+				)
+				esClient = newFakeEsClient(client, fakeResponses)
+			})
+
+			It("should expose kibana through an ingress and authenticate users with basic auth", func() {
+				Expect(Reconcile(logger, k8sCluster, client, esClient, nil, false, metav1.OwnerReference{})).Should(Succeed())
+
+				key := types.NamespacedName{Name: "kibana", Namespace: k8sCluster.GetNamespace()}
+				ing := &networkingv1.Ingress{}
+				Expect(client.Get(context.TODO(), key, ing)).Should(Succeed())
+				Expect(ing.Spec.Rules[0].Host).To(Equal("kibana.example.com"))
+
+				sa := &corev1.ServiceAccount{}
+				Expect(client.Get(context.TODO(), key, sa)).Should(Succeed())
+				Expect(sa.Annotations).ToNot(HaveKey("serviceaccounts.openshift.io/oauth-redirectreference.first"))
+
+				depl := &appsv1.Deployment{}
+				Expect(client.Get(context.TODO(), key, depl)).Should(Succeed())
+				proxyContainer := depl.Spec.Template.Spec.Containers[1]
+				Expect(proxyContainer.Image).To(Equal("quay.io/openshift/origin-oauth-proxy:latest"))
+				Expect(proxyContainer.Args).To(ContainElement("-htpasswd-file=/etc/proxy/htpasswd/auth"))
+				Expect(proxyContainer.Args).To(ContainElement("-provider=github"))
+				Expect(proxyContainer.Args).ToNot(ContainElement(HavePrefix("-client-secret-file")))
+				Expect(proxyContainer.Args).ToNot(ContainElement(HavePrefix("-openshift-")))
+				Expect(proxyContainer.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      "kibana-basic-auth",
+					ReadOnly:  true,
+					MountPath: "/etc/proxy/htpasswd",
+				}))
+			})
+
+			It("should require the image of the proxy", func() {
+				k8sCluster.Spec.ProxySpec.OAuth = nil
+
+				Expect(Reconcile(logger, k8sCluster, client, esClient, nil, false, metav1.OwnerReference{})).ShouldNot(Succeed())
+			})
+		})
 	})
 })
 
@@ -7,16 +7,23 @@ import (
 	"github.com/openshift/elasticsearch-operator/internal/elasticsearch/esclient"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type KibanaRequest struct {
-	log      logr.Logger
-	client   client.Client
-	cluster  *kibana.Kibana
-	esClient esclient.Client
+	log                 logr.Logger
+	client              client.Client
+	cluster             *kibana.Kibana
+	esClient            esclient.Client
+	fnSendKibanaRequest FnKibanaSendRequest
+	platform            kibana.KibanaPlatform
+	// savedObjects is the import status of the saved objects configmaps, nil until they are reconciled
+	savedObjects []kibana.KibanaSavedObjectsStatus
+	// health is the state reported by the status API of Kibana, nil until it is queried
+	health *kibanaHealth
 }
 
 // TODO: determine if this is even necessary
@@ -40,7 +47,7 @@ func (clusterRequest *KibanaRequest) UpdateStatus() error {
 			return err
 		}
 
-		if !compareKibanaStatus(kibanaStatus, clusterRequest.cluster.Status) {
+		if !equality.Semantic.DeepEqual(kibanaStatus, clusterRequest.cluster.Status) {
 			clusterRequest.cluster.Status = kibanaStatus
 			return clusterRequest.client.Status().Update(context.TODO(), clusterRequest.cluster)
 		}
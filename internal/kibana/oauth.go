@@ -0,0 +1,96 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	kibana "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	"github.com/openshift/elasticsearch-operator/internal/utils"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+const (
+	basicAuthVolumeName = "kibana-basic-auth"
+	basicAuthMountDir   = "/etc/proxy/htpasswd"
+	basicAuthSecretKey  = "auth"
+
+	// placeholder credentials of the unused provider of the basic auth proxy
+	basicAuthClientID     = "kibana-basic-auth"
+	basicAuthClientSecret = "unused"
+)
+
+// defaultOAuthScopes are the scopes the proxy needs to authenticate users and pass their token to Kibana
+var defaultOAuthScopes = []string{"user:info", "user:check-access", "user:list-projects"}
+
+// oauthSessionTimeout returns the expiry of the session cookie of the proxy, by order of precedence
+// the one of the spec, the access token inactivity timeout of the cluster or the default one
+func oauthSessionTimeout(spec *kibana.KibanaOAuthSpec, oauthConfig *configv1.OAuth) time.Duration {
+	if spec != nil && spec.SessionTimeout != nil {
+		return spec.SessionTimeout.Duration
+	}
+	if oauthConfig != nil && oauthConfig.Spec.TokenConfig.AccessTokenInactivityTimeout != nil {
+		return oauthConfig.Spec.TokenConfig.AccessTokenInactivityTimeout.Duration
+	}
+	return oauthTimeout
+}
+
+// oauthScopeArg returns the scopes requested by the proxy, the additional ones of the spec
+// follow the default ones
+func oauthScopeArg(spec *kibana.KibanaOAuthSpec) string {
+	scopes := append([]string{}, defaultOAuthScopes...)
+	if spec != nil {
+		for _, scope := range spec.AdditionalScopes {
+			if !utils.ContainsString(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return fmt.Sprintf("-scope=%s", strings.Join(scopes, " "))
+}
+
+// oauthRestrictionArgs returns the proxy arguments restricting the users allowed to log in
+func oauthRestrictionArgs(spec *kibana.KibanaOAuthSpec) []string {
+	if spec == nil {
+		return nil
+	}
+
+	var args []string
+	for _, group := range spec.AllowedGroups {
+		args = append(args, fmt.Sprintf("-openshift-group=%s", group))
+	}
+
+	if spec.SubjectAccessReview != nil {
+		// marshaling a struct of strings does not fail
+		sar, _ := json.Marshal(spec.SubjectAccessReview)
+		args = append(args, fmt.Sprintf("-openshift-sar=%s", sar))
+	}
+
+	return args
+}
+
+// BasicAuthProxyArgs returns the proxy arguments authenticating the users with the htpasswd
+// file of the basic auth secret, used where the OpenShift OAuth server is not available.
+// The proxy defaults to the openshift provider, which discovers the OAuth server of the
+// cluster at startup, and refuses to start without client credentials. The github provider
+// resolves its endpoints statically and is never reached as long as the users sign in with
+// the htpasswd form, so it is configured with placeholder credentials.
+func BasicAuthProxyArgs(cookieTimeout time.Duration) []string {
+	return []string{
+		"--https-address=:3000",
+		"-provider=github",
+		fmt.Sprintf("-client-id=%s", basicAuthClientID),
+		fmt.Sprintf("-client-secret=%s", basicAuthClientSecret),
+		fmt.Sprintf("-htpasswd-file=%s/%s", basicAuthMountDir, basicAuthSecretKey),
+		"-display-htpasswd-form=true",
+		"-email-domain=*",
+		"-cookie-secret-file=/secret/session-secret",
+		fmt.Sprintf("-cookie-expire=%s", cookieTimeout),
+		"-skip-auth-regex=^/api/status$",
+		"-upstream=http://localhost:5601",
+		"--tls-cert=/secret/server-cert",
+		"-tls-key=/secret/server-key",
+	}
+}
@@ -0,0 +1,130 @@
+package kibana
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+
+	configv1 "github.com/openshift/api/config/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOAuthSessionTimeout(t *testing.T) {
+	clusterConfig := &configv1.OAuth{
+		Spec: configv1.OAuthSpec{
+			TokenConfig: configv1.TokenConfig{
+				AccessTokenInactivityTimeout: &metav1.Duration{Duration: 12 * time.Hour},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc        string
+		spec        *loggingv1.KibanaOAuthSpec
+		oauthConfig *configv1.OAuth
+		want        time.Duration
+	}{
+		{
+			desc: "default timeout",
+			want: oauthTimeout,
+		},
+		{
+			desc:        "cluster inactivity timeout",
+			oauthConfig: clusterConfig,
+			want:        12 * time.Hour,
+		},
+		{
+			desc:        "session timeout of the spec",
+			spec:        &loggingv1.KibanaOAuthSpec{SessionTimeout: &metav1.Duration{Duration: 8 * time.Hour}},
+			oauthConfig: clusterConfig,
+			want:        8 * time.Hour,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			if got := oauthSessionTimeout(test.spec, test.oauthConfig); got != test.want {
+				t.Errorf("got timeout %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestOAuthProxyArgs(t *testing.T) {
+	tests := []struct {
+		desc      string
+		spec      *loggingv1.KibanaOAuthSpec
+		wantScope string
+		wantArgs  []string
+	}{
+		{
+			desc:      "no oauth spec",
+			wantScope: "-scope=user:info user:check-access user:list-projects",
+		},
+		{
+			desc: "restricted access",
+			spec: &loggingv1.KibanaOAuthSpec{
+				AllowedGroups: []string{"logging-admins", "developers"},
+				SubjectAccessReview: &authorizationv1.ResourceAttributes{
+					Namespace: "openshift-logging",
+					Verb:      "get",
+					Resource:  "pods",
+				},
+				AdditionalScopes: []string{"user:info", "user:full"},
+			},
+			wantScope: "-scope=user:info user:check-access user:list-projects user:full",
+			wantArgs: []string{
+				"-openshift-group=logging-admins",
+				"-openshift-group=developers",
+				`-openshift-sar={"namespace":"openshift-logging","verb":"get","resource":"pods"}`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			if got := oauthScopeArg(test.spec); got != test.wantScope {
+				t.Errorf("got scope %q, want %q", got, test.wantScope)
+			}
+			if diff := cmp.Diff(test.wantArgs, oauthRestrictionArgs(test.spec)); diff != "" {
+				t.Errorf("args mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBasicAuthProxyArgs(t *testing.T) {
+	want := []string{
+		"--https-address=:3000",
+		"-provider=github",
+		"-client-id=kibana-basic-auth",
+		"-client-secret=unused",
+		"-htpasswd-file=/etc/proxy/htpasswd/auth",
+		"-display-htpasswd-form=true",
+		"-email-domain=*",
+		"-cookie-secret-file=/secret/session-secret",
+		"-cookie-expire=24h0m0s",
+		"-skip-auth-regex=^/api/status$",
+		"-upstream=http://localhost:5601",
+		"--tls-cert=/secret/server-cert",
+		"-tls-key=/secret/server-key",
+	}
+
+	args := BasicAuthProxyArgs(24 * time.Hour)
+	if diff := cmp.Diff(want, args); diff != "" {
+		t.Errorf("args mismatch (-want +got):\n%s", diff)
+	}
+	for _, arg := range args {
+		for _, prefix := range []string{"-provider=openshift", "-client-secret-file", "-scope", "-openshift-", "-pass-access-token"} {
+			if strings.HasPrefix(arg, prefix) {
+				t.Errorf("unexpected openshift oauth arg %q", arg)
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package kibana
+
+import (
+	"context"
+
+	kibana "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/route"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// detectPlatform returns the platform of the spec or, when not set, the one detected from
+// the availability of the openshift route API
+func detectPlatform(c client.Client, cluster *kibana.Kibana) kibana.KibanaPlatform {
+	if cluster.Spec.Platform != "" {
+		return cluster.Spec.Platform
+	}
+	if route.RouteEnabled(context.TODO(), c, cluster.Namespace) {
+		return kibana.KibanaPlatformOpenShift
+	}
+	return kibana.KibanaPlatformKubernetes
+}
+
+// onKubernetes returns true if Kibana runs without the openshift routes, console and OAuth server
+func (clusterRequest *KibanaRequest) onKubernetes() bool {
+	return clusterRequest.platform == kibana.KibanaPlatformKubernetes
+}
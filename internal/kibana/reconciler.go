@@ -3,7 +3,6 @@ package kibana
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"time"
 
 	kibana "github.com/openshift/elasticsearch-operator/apis/logging/v1"
@@ -12,6 +11,7 @@ import (
 	"github.com/openshift/elasticsearch-operator/internal/elasticsearch/esclient"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/deployment"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/pod"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/poddisruptionbudget"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/secret"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/service"
 	"github.com/openshift/elasticsearch-operator/internal/utils"
@@ -23,6 +23,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -48,19 +49,29 @@ var kibanaServiceAccountAnnotations = map[string]string{
 
 func Reconcile(log logr.Logger, requestCluster *kibana.Kibana, requestClient client.Client, esClient esclient.Client, proxyConfig *configv1.Proxy, eoManagedCerts bool, ownerRef metav1.OwnerReference) error {
 	clusterKibanaRequest := KibanaRequest{
-		log:      log,
-		client:   requestClient,
-		cluster:  requestCluster,
-		esClient: esClient,
+		log:                 log,
+		client:              requestClient,
+		cluster:             requestCluster,
+		esClient:            esClient,
+		fnSendKibanaRequest: sendKibanaRequest,
 	}
 
 	if clusterKibanaRequest.cluster == nil {
 		return nil
 	}
 
+	clusterKibanaRequest.platform = detectPlatform(requestClient, requestCluster)
+
 	if eoManagedCerts {
-		cr := elasticsearch.NewCertificateRequest(log, ownerRef.Name, requestCluster.Namespace, ownerRef, requestClient)
-		cr.GenerateKibanaCerts(requestCluster.Name)
+		// owner references cannot cross namespaces, the secrets of a Kibana instance
+		// connecting to a cluster in another namespace are owned by the Kibana CR
+		secretOwnerRef := ownerRef
+		if esClient.ClusterNamespace() != requestCluster.Namespace {
+			secretOwnerRef = getOwnerRef(requestCluster)
+		}
+
+		cr := elasticsearch.NewCertificateRequest(log, ownerRef.Name, esClient.ClusterNamespace(), ownerRef, requestClient)
+		cr.GenerateKibanaCerts(requestCluster.Namespace, secretOwnerRef, clusterKibanaRequest.passthroughRouteHost())
 	}
 
 	// ensure that we have the certs pulled in from the secret first... required for route generation
@@ -68,7 +79,13 @@ func Reconcile(log logr.Logger, requestCluster *kibana.Kibana, requestClient cli
 		return err
 	}
 
-	if err := clusterKibanaRequest.CreateOrUpdateServiceAccount(kibanaServiceAccountName, kibanaServiceAccountAnnotations); err != nil {
+	// the OAuth redirect reference of the service account points to the route of Kibana
+	serviceAccountAnnotations := kibanaServiceAccountAnnotations
+	if clusterKibanaRequest.onKubernetes() {
+		serviceAccountAnnotations = nil
+	}
+
+	if err := clusterKibanaRequest.CreateOrUpdateServiceAccount(kibanaServiceAccountName, serviceAccountAnnotations); err != nil {
 		return err
 	}
 
@@ -76,14 +93,24 @@ func Reconcile(log logr.Logger, requestCluster *kibana.Kibana, requestClient cli
 		return err
 	}
 
-	if err := clusterKibanaRequest.createOrUpdateKibanaRoute(); err != nil {
+	if err := clusterKibanaRequest.createOrUpdateKibanaPodDisruptionBudget(); err != nil {
 		return err
 	}
 
+	if clusterKibanaRequest.onKubernetes() {
+		if err := clusterKibanaRequest.createOrUpdateKibanaIngress(); err != nil {
+			return err
+		}
+	} else {
+		if err := clusterKibanaRequest.createOrUpdateKibanaRoute(); err != nil {
+			return err
+		}
+	}
+
 	// we only want to create these if the use case is the CLO one
 	// make sure our namespace is "openshift-logging" and our cr name is "kibana"
 	// or do we just check that our owner ref is from a cluster logging object?
-	if clusterKibanaRequest.isCLOUseCase() {
+	if !clusterKibanaRequest.onKubernetes() && clusterKibanaRequest.isCLOUseCase() {
 		if err := clusterKibanaRequest.createOrUpdateKibanaConsoleExternalLogLink(); err != nil {
 			return err
 		}
@@ -97,11 +124,30 @@ func Reconcile(log logr.Logger, requestCluster *kibana.Kibana, requestClient cli
 		return err
 	}
 
-	clusterName := esClient.ClusterName()
-	if err := clusterKibanaRequest.createOrUpdateKibanaDeployment(proxyConfig, clusterName); err != nil {
+	if err := clusterKibanaRequest.createOrUpdateKibanaConfig(); err != nil {
+		return err
+	}
+
+	esServiceHost := fmt.Sprintf("%s.%s.svc", esClient.ClusterName(), esClient.ClusterNamespace())
+	if err := clusterKibanaRequest.createOrUpdateKibanaDeployment(proxyConfig, esServiceHost); err != nil {
+		return err
+	}
+
+	if err := clusterKibanaRequest.createOrUpdateKibanaAutoscaler(); err != nil {
 		return err
 	}
 
+	// the saved objects are provisioned on a best effort basis once kibana is rolled out
+	if err := clusterKibanaRequest.provisionSavedObjects(); err != nil {
+		log.Error(err, "failed to provision kibana saved objects")
+	}
+
+	if err := clusterKibanaRequest.importSavedObjectConfigMaps(); err != nil {
+		log.Error(err, "failed to import kibana saved objects of configmaps")
+	}
+
+	clusterKibanaRequest.health = clusterKibanaRequest.getKibanaHealth()
+
 	return clusterKibanaRequest.UpdateStatus()
 }
 
@@ -122,6 +168,10 @@ func GetProxyConfig(r client.Client) (*configv1.Proxy, error) {
 	proxyNamespacedName := types.NamespacedName{Name: constants.ProxyName}
 	proxyConfig := &configv1.Proxy{}
 	if err := r.Get(context.TODO(), proxyNamespacedName, proxyConfig); err != nil {
+		// clusters without the openshift config API have no cluster proxy
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
 		if !apierrors.IsNotFound(err) {
 			return nil, kverrors.Wrap(err, "encountered unexpected error getting proxy",
 				"proxy", proxyNamespacedName,
@@ -131,57 +181,6 @@ func GetProxyConfig(r client.Client) (*configv1.Proxy, error) {
 	return proxyConfig, nil
 }
 
-func compareKibanaStatus(lhs, rhs []kibana.KibanaStatus) bool {
-	// there should only ever be a single kibana status object
-	if len(lhs) != len(rhs) {
-		return false
-	}
-
-	if len(lhs) > 0 {
-		for index := range lhs {
-			if lhs[index].Deployment != rhs[index].Deployment {
-				return false
-			}
-
-			if lhs[index].Replicas != rhs[index].Replicas {
-				return false
-			}
-
-			if len(lhs[index].ReplicaSets) != len(rhs[index].ReplicaSets) {
-				return false
-			}
-
-			if len(lhs[index].ReplicaSets) > 0 {
-				if !reflect.DeepEqual(lhs[index].ReplicaSets, rhs[index].ReplicaSets) {
-					return false
-				}
-			}
-
-			if len(lhs[index].Pods) != len(rhs[index].Pods) {
-				return false
-			}
-
-			if len(lhs[index].Pods) > 0 {
-				if !reflect.DeepEqual(lhs[index].Pods, rhs[index].Pods) {
-					return false
-				}
-			}
-
-			if len(lhs[index].Conditions) != len(rhs[index].Conditions) {
-				return false
-			}
-
-			if len(lhs[index].Conditions) > 0 {
-				if !reflect.DeepEqual(lhs[index].Conditions, rhs[index].Conditions) {
-					return false
-				}
-			}
-		}
-	}
-
-	return true
-}
-
 func (clusterRequest *KibanaRequest) deleteKibana5Deployment() error {
 	kibana5 := &apps.Deployment{}
 	if err := clusterRequest.Get(clusterRequest.cluster.Name, kibana5); err != nil {
@@ -227,7 +226,7 @@ func (clusterRequest *KibanaRequest) isCLOUseCase() bool {
 	return false
 }
 
-func (clusterRequest *KibanaRequest) createOrUpdateKibanaDeployment(proxyConfig *configv1.Proxy, clusterName string) (err error) {
+func (clusterRequest *KibanaRequest) createOrUpdateKibanaDeployment(proxyConfig *configv1.Proxy, esServiceHost string) (err error) {
 	kibanaTrustBundle := &v1.ConfigMap{}
 
 	// Create cluster proxy trusted CA bundle.
@@ -238,24 +237,44 @@ func (clusterRequest *KibanaRequest) createOrUpdateKibanaDeployment(proxyConfig
 		}
 	}
 
-	oauthProxyImage, err := getProxyImage(context.TODO(), clusterRequest.client)
-	if err != nil {
-		return kverrors.Wrap(err, "Failed to get oauth-proxy image")
+	oauthSpec := clusterRequest.cluster.Spec.ProxySpec.OAuth
+
+	var oauthProxyImage string
+	switch {
+	case oauthSpec != nil && oauthSpec.Image != "":
+		oauthProxyImage = oauthSpec.Image
+	case clusterRequest.onKubernetes():
+		return kverrors.New("the image of the oauth proxy is required on kubernetes",
+			"cluster", clusterRequest.cluster.Name,
+			"namespace", clusterRequest.cluster.Namespace,
+		)
+	default:
+		oauthProxyImage, err = getProxyImage(context.TODO(), clusterRequest.client)
+		if err != nil {
+			return kverrors.Wrap(err, "Failed to get oauth-proxy image")
+		}
 	}
 
-	oauthConfig, err := getOAuthConfig(clusterRequest.client)
-	if err != nil {
-		return kverrors.Wrap(err, "Failed to get oauth config")
+	if clusterRequest.onKubernetes() && clusterRequest.cluster.Spec.ProxySpec.BasicAuth == nil {
+		return kverrors.New("basic auth of the proxy is required on kubernetes",
+			"cluster", clusterRequest.cluster.Name,
+			"namespace", clusterRequest.cluster.Namespace,
+		)
 	}
 
-	cookieTimeout := oauthTimeout
-	if oauthConfig != nil && oauthConfig.Spec.TokenConfig.AccessTokenInactivityTimeout != nil {
-		cookieTimeout = oauthConfig.Spec.TokenConfig.AccessTokenInactivityTimeout.Duration
+	var oauthConfig *configv1.OAuth
+	if !clusterRequest.onKubernetes() {
+		oauthConfig, err = getOAuthConfig(clusterRequest.client)
+		if err != nil {
+			return kverrors.Wrap(err, "Failed to get oauth config")
+		}
 	}
 
+	cookieTimeout := oauthSessionTimeout(oauthSpec, oauthConfig)
+
 	kibanaPodSpec := newKibanaPodSpec(
 		clusterRequest,
-		fmt.Sprintf("%s.%s.svc", clusterName, clusterRequest.cluster.Namespace),
+		esServiceHost,
 		proxyConfig,
 		cookieTimeout,
 		kibanaTrustBundle,
@@ -267,7 +286,7 @@ func (clusterRequest *KibanaRequest) createOrUpdateKibanaDeployment(proxyConfig
 		clusterRequest.cluster.Namespace,
 		"kibana",
 		"kibana",
-		clusterRequest.cluster.Spec.Replicas,
+		clusterRequest.minReplicas(),
 		kibanaPodSpec,
 	)
 
@@ -281,7 +300,13 @@ func (clusterRequest *KibanaRequest) createOrUpdateKibanaDeployment(proxyConfig
 
 	utils.AddOwnerRefToObject(kibanaDeployment, getOwnerRef(clusterRequest.cluster))
 
-	err = deployment.CreateOrUpdate(context.TODO(), clusterRequest.client, kibanaDeployment, compareDeployments, mutateDeployment)
+	equal, mutate := compareDeployments, mutateDeployment
+	if clusterRequest.cluster.Spec.Autoscaling != nil {
+		// the replicas are owned by the autoscaler
+		equal, mutate = compareDeploymentsIgnoringReplicas, mutateDeploymentIgnoringReplicas
+	}
+
+	err = deployment.CreateOrUpdate(context.TODO(), clusterRequest.client, kibanaDeployment, equal, mutate)
 	if err != nil {
 		return kverrors.Wrap(err, "failed to create or update kibana deployment",
 			"cluster", clusterRequest.cluster.Name,
@@ -299,31 +324,34 @@ func (clusterRequest *KibanaRequest) getKibanaAnnotations(deployment *apps.Deplo
 
 	annotations := deployment.Spec.Template.ObjectMeta.Annotations
 
-	kibanaTrustBundle := &v1.ConfigMap{}
-	kibanaTrustBundleName := types.NamespacedName{Name: constants.KibanaTrustedCAName, Namespace: clusterRequest.cluster.Namespace}
-	if err := clusterRequest.client.Get(context.TODO(), kibanaTrustBundleName, kibanaTrustBundle); err != nil {
-		if !apierrors.IsNotFound(err) {
-			return annotations, err
+	// the trusted CA bundle is injected by the cluster network operator of OpenShift
+	if !clusterRequest.onKubernetes() {
+		kibanaTrustBundle := &v1.ConfigMap{}
+		kibanaTrustBundleName := types.NamespacedName{Name: constants.KibanaTrustedCAName, Namespace: clusterRequest.cluster.Namespace}
+		if err := clusterRequest.client.Get(context.TODO(), kibanaTrustBundleName, kibanaTrustBundle); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return annotations, err
+			}
 		}
-	}
 
-	if _, ok := kibanaTrustBundle.Data[constants.TrustedCABundleKey]; !ok {
-		return annotations, kverrors.New("trust bundle does not yet contain expected key",
-			"bundle", kibanaTrustBundle.Name,
-			"key", constants.TrustedCABundleKey,
-		)
-	}
+		if _, ok := kibanaTrustBundle.Data[constants.TrustedCABundleKey]; !ok {
+			return annotations, kverrors.New("trust bundle does not yet contain expected key",
+				"bundle", kibanaTrustBundle.Name,
+				"key", constants.TrustedCABundleKey,
+			)
+		}
 
-	trustedCAHashValue, err := calcTrustedCAHashValue(kibanaTrustBundle)
-	if err != nil {
-		return annotations, kverrors.Wrap(err, "unable to calculate trusted CA value")
-	}
+		trustedCAHashValue, err := calcTrustedCAHashValue(kibanaTrustBundle)
+		if err != nil {
+			return annotations, kverrors.Wrap(err, "unable to calculate trusted CA value")
+		}
 
-	if trustedCAHashValue == "" {
-		return annotations, kverrors.New("did not receive hashvalue for trusted CA value")
-	}
+		if trustedCAHashValue == "" {
+			return annotations, kverrors.New("did not receive hashvalue for trusted CA value")
+		}
 
-	annotations[constants.TrustedCABundleHashName] = trustedCAHashValue
+		annotations[constants.TrustedCABundleHashName] = trustedCAHashValue
+	}
 
 	// generate secret hash
 	for _, secretName := range []string{"kibana", "kibana-proxy"} {
@@ -343,6 +371,10 @@ func (clusterRequest *KibanaRequest) getKibanaAnnotations(deployment *apps.Deplo
 		annotations[hashKey] = secretHashValue
 	}
 
+	if hash := configHash(clusterRequest.cluster.Spec.Config); hash != "" {
+		annotations[kibanaConfigHashName] = hash
+	}
+
 	return annotations, nil
 }
 
@@ -360,8 +392,8 @@ func compareDeployments(current, desired *apps.Deployment) bool {
 		return false
 	}
 
-	for _, secretName := range []string{"kibana", "kibana-proxy"} {
-		hashKey := fmt.Sprintf("%s%s", constants.SecretHashPrefix, secretName)
+	for _, name := range []string{"kibana", "kibana-proxy", kibanaConfigName} {
+		hashKey := fmt.Sprintf("%s%s", constants.SecretHashPrefix, name)
 		currentHash := current.Spec.Template.ObjectMeta.Annotations[hashKey]
 		desiredHash := desired.Spec.Template.ObjectMeta.Annotations[hashKey]
 
@@ -372,10 +404,36 @@ func compareDeployments(current, desired *apps.Deployment) bool {
 	return true
 }
 
+// compareDeploymentsIgnoringReplicas compares the deployments as compareDeployments, except for their replicas
+func compareDeploymentsIgnoringReplicas(current, desired *apps.Deployment) bool {
+	desiredCopy := desired.DeepCopy()
+	desiredCopy.Spec.Replicas = current.Spec.Replicas
+	return compareDeployments(current, desiredCopy)
+}
+
+// mutateDeploymentIgnoringReplicas mutates the deployment as mutateDeployment, except for its replicas
+func mutateDeploymentIgnoringReplicas(current, desired *apps.Deployment) {
+	desiredCopy := desired.DeepCopy()
+	desiredCopy.Spec.Replicas = current.Spec.Replicas
+	mutateDeployment(current, desiredCopy)
+}
+
 func mutateDeployment(current *apps.Deployment, desired *apps.Deployment) {
 	if !pod.ArePodTemplateSpecEqual(current.Spec.Template, desired.Spec.Template) {
+		current.Spec.Template.Labels = desired.Spec.Template.Labels
+		for key, value := range desired.Spec.Template.Annotations {
+			if current.Spec.Template.Annotations == nil {
+				current.Spec.Template.Annotations = map[string]string{}
+			}
+			current.Spec.Template.Annotations[key] = value
+		}
+
 		current.Spec.Template.Spec.NodeSelector = desired.Spec.Template.Spec.NodeSelector
 		current.Spec.Template.Spec.Tolerations = desired.Spec.Template.Spec.Tolerations
+		current.Spec.Template.Spec.PriorityClassName = desired.Spec.Template.Spec.PriorityClassName
+		current.Spec.Template.Spec.Affinity = desired.Spec.Template.Spec.Affinity
+		current.Spec.Template.Spec.TopologySpreadConstraints = desired.Spec.Template.Spec.TopologySpreadConstraints
+		current.Spec.Template.Spec.Volumes = desired.Spec.Template.Spec.Volumes
 
 		containers := current.Spec.Template.Spec.Containers
 
@@ -389,7 +447,10 @@ func mutateDeployment(current *apps.Deployment, desired *apps.Deployment) {
 					if !utils.EnvValueEqual(curr.Env, des.Env) {
 						containers[index].Env = des.Env
 					}
+					containers[index].EnvFrom = des.EnvFrom
+					containers[index].Command = des.Command
 					containers[index].Args = des.Args
+					containers[index].Ports = des.Ports
 					containers[index].Resources = des.Resources
 					containers[index].VolumeMounts = des.VolumeMounts
 				}
@@ -410,8 +471,8 @@ func mutateDeployment(current *apps.Deployment, desired *apps.Deployment) {
 		current.Spec.Template.ObjectMeta.Annotations[constants.TrustedCABundleHashName] = desiredTrustedCAHash
 	}
 
-	for _, secretName := range []string{"kibana", "kibana-proxy"} {
-		hashKey := fmt.Sprintf("%s%s", constants.SecretHashPrefix, secretName)
+	for _, name := range []string{"kibana", "kibana-proxy", kibanaConfigName} {
+		hashKey := fmt.Sprintf("%s%s", constants.SecretHashPrefix, name)
 		currentHash := current.Spec.Template.ObjectMeta.Annotations[hashKey]
 		desiredHash := desired.Spec.Template.ObjectMeta.Annotations[hashKey]
 
@@ -453,6 +514,39 @@ func (clusterRequest *KibanaRequest) createOrUpdateKibanaService() error {
 	return nil
 }
 
+// createOrUpdateKibanaPodDisruptionBudget keeps one kibana pod unavailable at most when
+// running more than one replica and removes the budget otherwise
+func (clusterRequest *KibanaRequest) createOrUpdateKibanaPodDisruptionBudget() error {
+	if clusterRequest.minReplicas() <= 1 {
+		key := client.ObjectKey{Name: "kibana", Namespace: clusterRequest.cluster.Namespace}
+		return poddisruptionbudget.Delete(context.TODO(), clusterRequest.client, key)
+	}
+
+	labels := map[string]string{
+		"logging-infra": "support",
+	}
+
+	pdb := poddisruptionbudget.New("kibana", clusterRequest.cluster.Namespace, labels).
+		WithSelector(map[string]string{
+			"component": "kibana",
+			"provider":  "openshift",
+		}).
+		WithMaxUnavailable(1).
+		Build()
+
+	utils.AddOwnerRefToObject(pdb, getOwnerRef(clusterRequest.cluster))
+
+	err := poddisruptionbudget.CreateOrUpdate(context.TODO(), clusterRequest.client, pdb, poddisruptionbudget.Equal, poddisruptionbudget.Mutate)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to create or update kibana poddisruptionbudget",
+			"cluster", clusterRequest.cluster.Name,
+			"namespace", clusterRequest.cluster.Namespace,
+		)
+	}
+
+	return nil
+}
+
 func getImage() string {
 	return utils.LookupEnvWithDefault("RELATED_IMAGE_KIBANA", kibanaDefaultImage)
 }
@@ -603,21 +697,26 @@ func newKibanaPodSpec(cluster *KibanaRequest, elasticsearchName string, proxyCon
 		*kibanaProxyResources,
 	)
 
-	kibanaProxyContainer.Args = []string{
-		"--upstream-ca=/var/run/secrets/kubernetes.io/serviceaccount/ca.crt",
-		"--https-address=:3000",
-		"-provider=openshift",
-		fmt.Sprintf("-client-id=system:serviceaccount:%s:kibana", cluster.cluster.Namespace),
-		"-client-secret-file=/var/run/secrets/kubernetes.io/serviceaccount/token",
-		"-cookie-secret-file=/secret/session-secret",
-		fmt.Sprintf("-cookie-expire=%s", oauthTimeout),
-		"-skip-provider-button",
-		"-skip-auth-regex=^/api/status$",
-		"-upstream=http://localhost:5601",
-		"-scope=user:info user:check-access user:list-projects",
-		"--tls-cert=/secret/server-cert",
-		"-tls-key=/secret/server-key",
-		"-pass-access-token",
+	if cluster.onKubernetes() {
+		kibanaProxyContainer.Args = BasicAuthProxyArgs(oauthTimeout)
+	} else {
+		kibanaProxyContainer.Args = []string{
+			"--upstream-ca=/var/run/secrets/kubernetes.io/serviceaccount/ca.crt",
+			"--https-address=:3000",
+			"-provider=openshift",
+			fmt.Sprintf("-client-id=system:serviceaccount:%s:kibana", cluster.cluster.Namespace),
+			"-client-secret-file=/var/run/secrets/kubernetes.io/serviceaccount/token",
+			"-cookie-secret-file=/secret/session-secret",
+			fmt.Sprintf("-cookie-expire=%s", oauthTimeout),
+			"-skip-provider-button",
+			"-skip-auth-regex=^/api/status$",
+			"-upstream=http://localhost:5601",
+			oauthScopeArg(visSpec.ProxySpec.OAuth),
+			"--tls-cert=/secret/server-cert",
+			"-tls-key=/secret/server-key",
+			"-pass-access-token",
+		}
+		kibanaProxyContainer.Args = append(kibanaProxyContainer.Args, oauthRestrictionArgs(visSpec.ProxySpec.OAuth)...)
 	}
 
 	kibanaProxyContainer.Env = []v1.EnvVar{
@@ -657,6 +756,17 @@ func newKibanaPodSpec(cluster *KibanaRequest, elasticsearchName string, proxyCon
 			})
 	}
 
+	// On Kubernetes, the proxy authenticates users with the htpasswd file of the basic auth secret
+	addBasicAuthVolume := cluster.onKubernetes() && visSpec.ProxySpec.BasicAuth != nil
+	if addBasicAuthVolume {
+		kibanaProxyContainer.VolumeMounts = append(kibanaProxyContainer.VolumeMounts,
+			v1.VolumeMount{
+				Name:      basicAuthVolumeName,
+				ReadOnly:  true,
+				MountPath: basicAuthMountDir,
+			})
+	}
+
 	kibanaPodSpec := pod.NewSpec(
 		"kibana",
 		[]v1.Container{kibanaContainer, kibanaProxyContainer},
@@ -684,6 +794,18 @@ func newKibanaPodSpec(cluster *KibanaRequest, elasticsearchName string, proxyCon
 		WithSecurityContext(utils.PodSecurityContext()).
 		Build()
 
+	if addBasicAuthVolume {
+		kibanaPodSpec.Volumes = append(kibanaPodSpec.Volumes,
+			v1.Volume{
+				Name: basicAuthVolumeName,
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{
+						SecretName: visSpec.ProxySpec.BasicAuth.SecretName,
+					},
+				},
+			})
+	}
+
 	if addTrustedCAVolume {
 		kibanaPodSpec.Volumes = append(kibanaPodSpec.Volumes,
 			v1.Volume{
@@ -704,6 +826,10 @@ func newKibanaPodSpec(cluster *KibanaRequest, elasticsearchName string, proxyCon
 			})
 	}
 
+	if len(appliedConfig(visSpec.Config)) > 0 {
+		withConfig(kibanaPodSpec)
+	}
+
 	kibanaPodSpec.Affinity = &v1.Affinity{
 		PodAntiAffinity: &v1.PodAntiAffinity{
 			PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
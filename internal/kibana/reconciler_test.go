@@ -368,6 +368,37 @@ func TestDeploymentDifferentWithKibanaReplicas(t *testing.T) {
 	}
 }
 
+func TestDeploymentDifferentWithKibanaScheduling(t *testing.T) {
+	clusterRequest := &KibanaRequest{
+		cluster: &kibana.Kibana{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test-namespace",
+			},
+			Spec: kibana.KibanaSpec{
+				Replicas: 1,
+			},
+		},
+	}
+	lhsPodSpec := newKibanaPodSpec(clusterRequest, "test-app-name", nil, oauthTimeout, nil, "")
+	lhsDeployment := NewDeployment("kibana", clusterRequest.cluster.Namespace, "kibana", "kibana", 1, lhsPodSpec)
+
+	rhsPodSpec := newKibanaPodSpec(clusterRequest, "test-app-name", nil, oauthTimeout, nil, "")
+	rhsPodSpec.PriorityClassName = "logging-critical"
+	rhsPodSpec.Affinity = &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{}}
+	rhsPodSpec.Volumes = append(rhsPodSpec.Volumes, v1.Volume{Name: "extra"})
+	rhsDeployment := NewDeployment("kibana", clusterRequest.cluster.Namespace, "kibana", "kibana", 1, rhsPodSpec)
+	rhsDeployment.Spec.Template.Labels["team"] = "logging"
+
+	if compareDeployments(lhsDeployment, rhsDeployment) {
+		t.Fatalf("Exp. the deployments to differ by their scheduling")
+	}
+
+	mutateDeployment(lhsDeployment, rhsDeployment)
+	if !compareDeployments(lhsDeployment, rhsDeployment) {
+		t.Errorf("Exp. the mutated deployment to converge, diff:\n%s", cmp.Diff(rhsDeployment.Spec.Template, lhsDeployment.Spec.Template))
+	}
+}
+
 func TestDeploymentDifferentWithProxyEnvVar(t *testing.T) {
 	replicas := int32(1)
 	clusterRequest := &KibanaRequest{
@@ -7,18 +7,24 @@ import (
 	"strings"
 
 	"github.com/ViaQ/logerr/v2/kverrors"
+	kibana "github.com/openshift/elasticsearch-operator/apis/logging/v1"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/console"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/route"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/secret"
 	"github.com/openshift/elasticsearch-operator/internal/utils"
 
 	routev1 "github.com/openshift/api/route/v1"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const KibanaConsoleLinkName = "kibana-public-url"
 
+// routeCertificateCAKey is the key of the optional CA certificate in the certificate secret of the route
+const routeCertificateCAKey = "ca.crt"
+
 const icon = "data:image/svg+xml;base64,PHN2ZyBpZD0iYWZiNDE1NDktYzU3MC00OWI3LTg1Y2QtNjU3NjAwZWRmMmUxIiBkYXRhLW5hbWU9IkxheWVyIDEiIHhtbG5zPSJodHRwOi8vd3d3LnczLm9yZy8yMDAwL3N2ZyIgdmlld0JveD0iMCAwIDcyMS4xNSA3MjEuMTUiPgogIDxkZWZzPgogICAgPHN0eWxlPgogICAgICAuYTQ0OGZkZWEtNGE0Yy00Njc4LTk3NmEtYzM3ODUzMDhhZTA2IHsKICAgICAgICBmaWxsOiAjZGIzOTI3OwogICAgICB9CgogICAgICAuZTEzMzA4YjgtNzQ4NS00Y2IwLTk3NjUtOGE1N2I5M2Y5MWE2IHsKICAgICAgICBmaWxsOiAjY2IzNzI4OwogICAgICB9CgogICAgICAuZTc3Mjg2ZjEtMjJkYS00NGQxLThlZmItMWQxNGIwY2NhZTYyIHsKICAgICAgICBmaWxsOiAjZmZmOwogICAgICB9CgogICAgICAuYTA0MjBjYWMtZWJlNi00YzE4LWI5ODEtYWJiYTBiYTliMzY1IHsKICAgICAgICBmaWxsOiAjZTVlNWU0OwogICAgICB9CiAgICA8L3N0eWxlPgogIDwvZGVmcz4KICA8Y2lyY2xlIGNsYXNzPSJhNDQ4ZmRlYS00YTRjLTQ2NzgtOTc2YS1jMzc4NTMwOGFlMDYiIGN4PSIzNjAuNTgiIGN5PSIzNjAuNTgiIHI9IjM1OC4yOCIvPgogIDxwYXRoIGNsYXNzPSJlMTMzMDhiOC03NDg1LTRjYjAtOTc2NS04YTU3YjkzZjkxYTYiIGQ9Ik02MTMuNTQsMTA3LjMsMTA2Ljg4LDYxNGMxNDAsMTM4LjUxLDM2NS44MiwxMzguMDYsNTA1LjI2LTEuMzlTNzUyLDI0Ny4zMyw2MTMuNTQsMTA3LjNaIi8+CiAgPGc+CiAgICA8Y2lyY2xlIGNsYXNzPSJlNzcyODZmMS0yMmRhLTQ0ZDEtOGVmYi0xZDE0YjBjY2FlNjIiIGN4PSIyMzQuNyIgY3k9IjM1Ny4zIiByPSI0Ny43MiIvPgogICAgPGNpcmNsZSBjbGFzcz0iZTc3Mjg2ZjEtMjJkYS00NGQxLThlZmItMWQxNGIwY2NhZTYyIiBjeD0iMjM0LjciIGN5PSIxODIuOTQiIHI9IjQ3LjcyIi8+CiAgICA8Y2lyY2xlIGNsYXNzPSJlNzcyODZmMS0yMmRhLTQ0ZDEtOGVmYi0xZDE0YjBjY2FlNjIiIGN4PSIyMzQuNyIgY3k9IjUzOC4yMSIgcj0iNDcuNzIiLz4KICA8L2c+CiAgPHBvbHlnb24gY2xhc3M9ImU3NzI4NmYxLTIyZGEtNDRkMS04ZWZiLTFkMTRiMGNjYWU2MiIgcG9pbnRzPSI0MzUuMTkgMzQ3LjMgMzkwLjU0IDM0Ny4zIDM5MC41NCAxNzIuOTQgMzE2LjE2IDE3Mi45NCAzMTYuMTYgMTkyLjk0IDM3MC41NCAxOTIuOTQgMzcwLjU0IDM0Ny4zIDMxNi4xNiAzNDcuMyAzMTYuMTYgMzY3LjMgMzcwLjU0IDM2Ny4zIDM3MC41NCA1MjEuNjcgMzE2LjE2IDUyMS42NyAzMTYuMTYgNTQxLjY3IDM5MC41NCA1NDEuNjcgMzkwLjU0IDM2Ny4zIDQzNS4xOSAzNjcuMyA0MzUuMTkgMzQ3LjMiLz4KICA8cG9seWdvbiBjbGFzcz0iZTc3Mjg2ZjEtMjJkYS00NGQxLThlZmItMWQxNGIwY2NhZTYyIiBwb2ludHM9IjU5OS43NCAzMTcuMDMgNTU3Ljk3IDMxNy4wMyA1NTAuOTcgMzE3LjAzIDU1MC45NyAzMTAuMDMgNTUwLjk3IDI2OC4yNiA1NTAuOTcgMjY4LjI2IDQ2NC4zNiAyNjguMjYgNDY0LjM2IDQ0Ni4zNCA1OTkuNzQgNDQ2LjM0IDU5OS43NCAzMTcuMDMgNTk5Ljc0IDMxNy4wMyIvPgogIDxwb2x5Z29uIGNsYXNzPSJhMDQyMGNhYy1lYmU2LTRjMTgtYjk4MS1hYmJhMGJhOWIzNjUiIHBvaW50cz0iNTk5Ljc0IDMxMC4wMyA1NTcuOTcgMjY4LjI2IDU1Ny45NyAzMTAuMDMgNTk5Ljc0IDMxMC4wMyIvPgo8L3N2Zz4K"
 
 // GetRouteURL retrieves the route URL from a given route and namespace
@@ -38,6 +44,13 @@ func (clusterRequest *KibanaRequest) GetRouteURL(routeName string) (string, erro
 func (clusterRequest *KibanaRequest) createOrUpdateKibanaRoute() error {
 	cluster := clusterRequest.cluster
 
+	if err := validateRouteSpec(cluster.Spec.Route); err != nil {
+		return kverrors.Wrap(err, "invalid Kibana route spec",
+			"cluster", cluster.Name,
+			"namespace", cluster.Namespace,
+		)
+	}
+
 	fp := utils.GetWorkingDirFilePath("ca.crt")
 	caCert, err := ioutil.ReadFile(fp)
 	if err != nil {
@@ -46,6 +59,31 @@ func (clusterRequest *KibanaRequest) createOrUpdateKibanaRoute() error {
 			"cause", err)
 	}
 
+	tlsConfig := &routev1.TLSConfig{
+		Termination:                   routev1.TLSTerminationReencrypt,
+		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+		DestinationCACertificate:      string(caCert),
+	}
+
+	var host string
+	var annotations map[string]string
+	if spec := cluster.Spec.Route; spec != nil {
+		host = spec.Host
+		annotations = spec.Annotations
+
+		switch {
+		case spec.Termination == kibana.KibanaRouteTerminationPassthrough:
+			tlsConfig = &routev1.TLSConfig{
+				Termination:                   routev1.TLSTerminationPassthrough,
+				InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+			}
+		case spec.CertificateSecretName != "":
+			if err := clusterRequest.addRouteCertificate(tlsConfig, spec.CertificateSecretName); err != nil {
+				return err
+			}
+		}
+	}
+
 	labels := map[string]string{
 		"component":     "support",
 		"logging-infra": "support",
@@ -53,16 +91,18 @@ func (clusterRequest *KibanaRequest) createOrUpdateKibanaRoute() error {
 	}
 
 	rt := route.New("kibana", cluster.Namespace, "kibana", labels).
-		WithTLSConfig(&routev1.TLSConfig{
-			Termination:                   routev1.TLSTerminationReencrypt,
-			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
-			DestinationCACertificate:      string(caCert),
-		}).
+		WithHost(host).
+		WithAnnotations(annotations).
+		WithTLSConfig(tlsConfig).
 		Build()
 
 	utils.AddOwnerRefToObject(rt, getOwnerRef(cluster))
 
-	err = route.CreateOrUpdate(context.TODO(), clusterRequest.client, rt, route.RouteTLSConfigEqual, route.MutateTLSConfigOnly)
+	if err := clusterRequest.deleteRouteWithDroppedHost(rt); err != nil {
+		return err
+	}
+
+	err = route.CreateOrUpdate(context.TODO(), clusterRequest.client, rt, route.RouteHostAndTLSConfigEqual, route.MutateHostAndTLSConfig)
 	if err != nil {
 		return kverrors.Wrap(err, "failed to update Kibana route for cluster",
 			"cluster", cluster.Name,
@@ -73,6 +113,80 @@ func (clusterRequest *KibanaRequest) createOrUpdateKibanaRoute() error {
 	return nil
 }
 
+// validateRouteSpec rejects a certificate secret for passthrough routes, where the router
+// does not present any certificate
+func validateRouteSpec(spec *kibana.KibanaRouteSpec) error {
+	if spec != nil && spec.Termination == kibana.KibanaRouteTerminationPassthrough && spec.CertificateSecretName != "" {
+		return kverrors.New("certificateSecretName is not supported with passthrough termination",
+			"secret", spec.CertificateSecretName)
+	}
+	return nil
+}
+
+// deleteRouteWithDroppedHost deletes the kibana route when its custom host was removed
+// from the spec, so it is recreated with the host generated by the router
+func (clusterRequest *KibanaRequest) deleteRouteWithDroppedHost(desired *routev1.Route) error {
+	key := client.ObjectKeyFromObject(desired)
+	current, err := route.Get(context.TODO(), clusterRequest.client, key)
+	if err != nil {
+		if apierrors.IsNotFound(kverrors.Root(err)) {
+			return nil
+		}
+		return err
+	}
+
+	if !route.CustomHostDropped(current, desired) {
+		return nil
+	}
+
+	clusterRequest.log.Info("Recreating the kibana route to reset its custom host", "host", current.Spec.Host)
+	return route.Delete(context.TODO(), clusterRequest.client, key)
+}
+
+// addRouteCertificate sets the certificate of the secret as the one presented by the router
+func (clusterRequest *KibanaRequest) addRouteCertificate(tlsConfig *routev1.TLSConfig, secretName string) error {
+	key := client.ObjectKey{Name: secretName, Namespace: clusterRequest.cluster.Namespace}
+	s, err := secret.Get(context.TODO(), clusterRequest.client, key)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to get the certificate of the kibana route",
+			"secret", secretName,
+			"namespace", clusterRequest.cluster.Namespace,
+		)
+	}
+
+	if len(s.Data[corev1.TLSCertKey]) == 0 || len(s.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return kverrors.New("certificate secret of the kibana route misses tls.crt or tls.key",
+			"secret", secretName,
+			"namespace", clusterRequest.cluster.Namespace,
+		)
+	}
+
+	tlsConfig.Certificate = string(s.Data[corev1.TLSCertKey])
+	tlsConfig.Key = string(s.Data[corev1.TLSPrivateKeyKey])
+	tlsConfig.CACertificate = string(s.Data[routeCertificateCAKey])
+
+	return nil
+}
+
+// passthroughRouteHost returns the host name of a passthrough route, which the certificate
+// of the proxy needs to cover. It is the one generated by the router when the spec has none.
+func (clusterRequest *KibanaRequest) passthroughRouteHost() string {
+	spec := clusterRequest.cluster.Spec.Route
+	if clusterRequest.onKubernetes() || spec == nil || spec.Termination != kibana.KibanaRouteTerminationPassthrough {
+		return ""
+	}
+	if spec.Host != "" {
+		return spec.Host
+	}
+
+	key := client.ObjectKey{Name: "kibana", Namespace: clusterRequest.cluster.Namespace}
+	rt, err := route.Get(context.TODO(), clusterRequest.client, key)
+	if err != nil {
+		return ""
+	}
+	return rt.Spec.Host
+}
+
 func (clusterRequest *KibanaRequest) createOrUpdateKibanaConsoleLink() error {
 	cluster := clusterRequest.cluster
 
@@ -0,0 +1,246 @@
+package kibana
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/route"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestCreateOrUpdateKibanaRoute(t *testing.T) {
+	utilruntime.Must(routev1.AddToScheme(scheme.Scheme))
+
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kibana-route-cert", Namespace: "openshift-logging"},
+		Data: map[string][]byte{
+			"tls.crt": []byte("the-cert"),
+			"tls.key": []byte("the-key"),
+			"ca.crt":  []byte("the-ca"),
+		},
+	}
+
+	tests := []struct {
+		desc            string
+		spec            *loggingv1.KibanaRouteSpec
+		wantHost        string
+		wantTermination routev1.TLSTerminationType
+		wantCertificate string
+		wantAnnotations map[string]string
+		wantErr         bool
+	}{
+		{
+			desc:            "default route",
+			wantTermination: routev1.TLSTerminationReencrypt,
+		},
+		{
+			desc: "reencrypt route with host, certificate and annotations",
+			spec: &loggingv1.KibanaRouteSpec{
+				Host:                  "kibana.example.com",
+				CertificateSecretName: "kibana-route-cert",
+				Annotations:           map[string]string{"haproxy.router.openshift.io/timeout": "5m"},
+			},
+			wantHost:        "kibana.example.com",
+			wantTermination: routev1.TLSTerminationReencrypt,
+			wantCertificate: "the-cert",
+			wantAnnotations: map[string]string{"haproxy.router.openshift.io/timeout": "5m"},
+		},
+		{
+			desc: "passthrough route",
+			spec: &loggingv1.KibanaRouteSpec{
+				Host:        "kibana.example.com",
+				Termination: loggingv1.KibanaRouteTerminationPassthrough,
+			},
+			wantHost:        "kibana.example.com",
+			wantTermination: routev1.TLSTerminationPassthrough,
+		},
+		{
+			desc: "certificate secret for passthrough route",
+			spec: &loggingv1.KibanaRouteSpec{
+				Termination:           loggingv1.KibanaRouteTerminationPassthrough,
+				CertificateSecretName: "kibana-route-cert",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "missing certificate secret",
+			spec: &loggingv1.KibanaRouteSpec{
+				CertificateSecretName: "missing",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(certSecret).Build()
+			clusterRequest := &KibanaRequest{
+				client: k8sClient,
+				cluster: &loggingv1.Kibana{
+					ObjectMeta: metav1.ObjectMeta{Name: "kibana", Namespace: "openshift-logging"},
+					Spec:       loggingv1.KibanaSpec{Route: test.spec},
+				},
+				log: log.Log,
+			}
+
+			err := clusterRequest.createOrUpdateKibanaRoute()
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			rt := &routev1.Route{}
+			key := types.NamespacedName{Name: "kibana", Namespace: "openshift-logging"}
+			if err := k8sClient.Get(context.TODO(), key, rt); err != nil {
+				t.Fatalf("expected the route to be created, got %v", err)
+			}
+
+			if rt.Spec.Host != test.wantHost {
+				t.Errorf("got host %q, want %q", rt.Spec.Host, test.wantHost)
+			}
+			if rt.Spec.TLS.Termination != test.wantTermination {
+				t.Errorf("got termination %q, want %q", rt.Spec.TLS.Termination, test.wantTermination)
+			}
+			if rt.Spec.TLS.Certificate != test.wantCertificate {
+				t.Errorf("got certificate %q, want %q", rt.Spec.TLS.Certificate, test.wantCertificate)
+			}
+			for k, v := range test.wantAnnotations {
+				if rt.Annotations[k] != v {
+					t.Errorf("expected annotation %s=%s, got %v", k, v, rt.Annotations)
+				}
+			}
+
+			wantPassthroughHost := ""
+			if test.wantTermination == routev1.TLSTerminationPassthrough {
+				wantPassthroughHost = test.wantHost
+			}
+			if got := clusterRequest.passthroughRouteHost(); got != wantPassthroughHost {
+				t.Errorf("got passthrough host %q, want %q", got, wantPassthroughHost)
+			}
+		})
+	}
+}
+
+func TestCreateOrUpdateKibanaRouteRemovesDroppedAnnotations(t *testing.T) {
+	utilruntime.Must(routev1.AddToScheme(scheme.Scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	cluster := &loggingv1.Kibana{
+		ObjectMeta: metav1.ObjectMeta{Name: "kibana", Namespace: "openshift-logging"},
+		Spec: loggingv1.KibanaSpec{
+			Route: &loggingv1.KibanaRouteSpec{
+				Annotations: map[string]string{
+					"haproxy.router.openshift.io/timeout": "5m",
+					"haproxy.router.openshift.io/balance": "roundrobin",
+				},
+			},
+		},
+	}
+	clusterRequest := &KibanaRequest{client: k8sClient, cluster: cluster, log: log.Log}
+	key := types.NamespacedName{Name: "kibana", Namespace: "openshift-logging"}
+
+	if err := clusterRequest.createOrUpdateKibanaRoute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// annotations set by others are kept
+	rt := &routev1.Route{}
+	if err := k8sClient.Get(context.TODO(), key, rt); err != nil {
+		t.Fatalf("expected the route to be created, got %v", err)
+	}
+	rt.Annotations["openshift.io/host.generated"] = "true"
+	if err := k8sClient.Update(context.TODO(), rt); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cluster.Spec.Route.Annotations = map[string]string{"haproxy.router.openshift.io/timeout": "10m"}
+	if err := clusterRequest.createOrUpdateKibanaRoute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := k8sClient.Get(context.TODO(), key, rt); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]string{
+		"haproxy.router.openshift.io/timeout": "10m",
+		"openshift.io/host.generated":         "true",
+		route.AppliedAnnotationsKey:           "haproxy.router.openshift.io/timeout",
+	}
+	if diff := cmp.Diff(want, rt.Annotations); diff != "" {
+		t.Errorf("annotations mismatch (-want +got):\n%s", diff)
+	}
+
+	cluster.Spec.Route.Annotations = nil
+	if err := clusterRequest.createOrUpdateKibanaRoute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := k8sClient.Get(context.TODO(), key, rt); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want = map[string]string{"openshift.io/host.generated": "true"}
+	if diff := cmp.Diff(want, rt.Annotations); diff != "" {
+		t.Errorf("annotations mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCreateOrUpdateKibanaRouteResetsDroppedHost(t *testing.T) {
+	utilruntime.Must(routev1.AddToScheme(scheme.Scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	cluster := &loggingv1.Kibana{
+		ObjectMeta: metav1.ObjectMeta{Name: "kibana", Namespace: "openshift-logging"},
+		Spec: loggingv1.KibanaSpec{
+			Route: &loggingv1.KibanaRouteSpec{Host: "kibana.example.com"},
+		},
+	}
+	clusterRequest := &KibanaRequest{client: k8sClient, cluster: cluster, log: log.Log}
+	key := types.NamespacedName{Name: "kibana", Namespace: "openshift-logging"}
+
+	if err := clusterRequest.createOrUpdateKibanaRoute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cluster.Spec.Route.Host = ""
+	if err := clusterRequest.createOrUpdateKibanaRoute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	rt := &routev1.Route{}
+	if err := k8sClient.Get(context.TODO(), key, rt); err != nil {
+		t.Fatalf("expected the route to be recreated, got %v", err)
+	}
+	if rt.Spec.Host != "" {
+		t.Errorf("expected the custom host to be reset, got %q", rt.Spec.Host)
+	}
+
+	// a generated host is kept
+	rt.Spec.Host = "kibana-openshift-logging.apps.example.com"
+	rt.Annotations = map[string]string{route.HostGeneratedAnnotation: "true"}
+	if err := k8sClient.Update(context.TODO(), rt); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := clusterRequest.createOrUpdateKibanaRoute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := k8sClient.Get(context.TODO(), key, rt); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rt.Spec.Host != "kibana-openshift-logging.apps.example.com" {
+		t.Errorf("expected the generated host to be kept, got %q", rt.Spec.Host)
+	}
+}
@@ -0,0 +1,320 @@
+package kibana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	"github.com/go-logr/logr"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/deployment"
+	"github.com/openshift/elasticsearch-operator/internal/manifests/pod"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kibanaAPIPort         = 5601
+	kibanaAPITimeout      = 10 * time.Second
+	defaultTimeFieldName  = "@timestamp"
+	indexPatternType      = "index-pattern"
+	kibanaSAToken         = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	savedObjectsBulkURI   = "/api/saved_objects/_bulk_create"
+	kibanaSettingsURI     = "/api/kibana/settings"
+	defaultIndexSettingID = "defaultIndex"
+	// globalTenant is the security tenant shared by all users. Without the header
+	// the saved objects end up in the private tenant of the operator service account.
+	globalTenant = "global"
+)
+
+// KibanaAPIRequest is a request to the REST API of a Kibana pod
+type KibanaAPIRequest struct {
+	Method      string
+	URI         string
+	ContentType string
+	RequestBody string
+	// Tenant is the security tenant of the saved objects, sent as securitytenant header
+	Tenant          string
+	StatusCode      int
+	RawResponseBody string
+	Error           error
+}
+
+type FnKibanaSendRequest func(log logr.Logger, host string, payload *KibanaAPIRequest)
+
+type savedObject struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Error      *savedObjectError      `json:"error,omitempty"`
+}
+
+type savedObjectError struct {
+	StatusCode int    `json:"statusCode"`
+	Message    string `json:"message"`
+}
+
+type bulkCreateResponse struct {
+	SavedObjects []savedObject `json:"saved_objects"`
+}
+
+type settingValue struct {
+	UserValue interface{} `json:"userValue,omitempty"`
+}
+
+type settingsResponse struct {
+	Settings map[string]settingValue `json:"settings"`
+}
+
+// provisionSavedObjects creates the index patterns of the spec and sets the default index through
+// the API of a ready Kibana pod. It waits for the rollout of the deployment to complete.
+func (clusterRequest *KibanaRequest) provisionSavedObjects() error {
+	spec := clusterRequest.cluster.Spec
+	if len(spec.IndexPatterns) == 0 && spec.DefaultIndex == "" {
+		return nil
+	}
+
+	key := client.ObjectKey{Name: "kibana", Namespace: clusterRequest.cluster.Namespace}
+	dpl, err := deployment.Get(context.TODO(), clusterRequest.client, key)
+	if err != nil {
+		return err
+	}
+	if !isRolledOut(dpl) {
+		return nil
+	}
+
+	host, err := clusterRequest.kibanaAPIHost()
+	if err != nil || host == "" {
+		return err
+	}
+
+	if err := clusterRequest.createIndexPatterns(host); err != nil {
+		return err
+	}
+
+	return clusterRequest.updateDefaultIndex(host)
+}
+
+// isRolledOut returns true when all the replicas of the deployment run the latest pod template
+func isRolledOut(dpl *apps.Deployment) bool {
+	replicas := int32(1)
+	if dpl.Spec.Replicas != nil {
+		replicas = *dpl.Spec.Replicas
+	}
+
+	return dpl.Status.ObservedGeneration >= dpl.Generation &&
+		dpl.Status.UpdatedReplicas == replicas &&
+		dpl.Status.Replicas == replicas &&
+		dpl.Status.AvailableReplicas == replicas
+}
+
+// kibanaAPIHost returns the address of the API of a ready Kibana pod or an empty one if none is ready
+func (clusterRequest *KibanaRequest) kibanaAPIHost() (string, error) {
+	pods, err := clusterRequest.readyKibanaPods()
+	if err != nil || len(pods) == 0 {
+		return "", err
+	}
+
+	return kibanaPodAPIHost(pods[0]), nil
+}
+
+// readyKibanaPods returns the running and ready Kibana pods, sorted by name
+func (clusterRequest *KibanaRequest) readyKibanaPods() ([]v1.Pod, error) {
+	pods, err := pod.List(context.TODO(), clusterRequest.client, clusterRequest.cluster.Namespace, map[string]string{
+		"component": "kibana",
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	ready := []v1.Pod{}
+	for _, p := range pods {
+		if p.Status.Phase == v1.PodRunning && p.Status.PodIP != "" && isPodReady(p) {
+			ready = append(ready, p)
+		}
+	}
+
+	return ready, nil
+}
+
+// kibanaPodAPIHost returns the address of the API of the Kibana container of the pod
+func kibanaPodAPIHost(p v1.Pod) string {
+	return net.JoinHostPort(p.Status.PodIP, strconv.Itoa(kibanaAPIPort))
+}
+
+// createIndexPatterns creates the index patterns of the spec, the ones which already exist are left untouched
+func (clusterRequest *KibanaRequest) createIndexPatterns(host string) error {
+	patterns := clusterRequest.cluster.Spec.IndexPatterns
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	objects := make([]savedObject, 0, len(patterns))
+	for _, p := range patterns {
+		timeFieldName := p.TimeFieldName
+		if timeFieldName == "" {
+			timeFieldName = defaultTimeFieldName
+		}
+		objects = append(objects, savedObject{
+			Type: indexPatternType,
+			ID:   p.Title,
+			Attributes: map[string]interface{}{
+				"title":         p.Title,
+				"timeFieldName": timeFieldName,
+			},
+		})
+	}
+
+	body, err := json.Marshal(objects)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to marshal index patterns")
+	}
+
+	payload := &KibanaAPIRequest{
+		Method:      http.MethodPost,
+		URI:         savedObjectsBulkURI,
+		RequestBody: string(body),
+		Tenant:      globalTenant,
+	}
+	clusterRequest.fnSendKibanaRequest(clusterRequest.log, host, payload)
+	if err := kibanaAPIError(payload); err != nil {
+		return kverrors.Wrap(err, "failed to create kibana index patterns")
+	}
+
+	res := bulkCreateResponse{}
+	if err := json.Unmarshal([]byte(payload.RawResponseBody), &res); err != nil {
+		return kverrors.Wrap(err, "failed to parse kibana index patterns response")
+	}
+
+	for _, obj := range res.SavedObjects {
+		if obj.Error != nil && obj.Error.StatusCode != http.StatusConflict {
+			return kverrors.New("failed to create kibana index pattern",
+				"index_pattern", obj.ID,
+				"status", obj.Error.StatusCode,
+				"message", obj.Error.Message,
+			)
+		}
+	}
+
+	return nil
+}
+
+// updateDefaultIndex sets the index pattern of the spec as default index when it differs
+func (clusterRequest *KibanaRequest) updateDefaultIndex(host string) error {
+	defaultIndex := clusterRequest.cluster.Spec.DefaultIndex
+	if defaultIndex == "" {
+		return nil
+	}
+
+	payload := &KibanaAPIRequest{
+		Method: http.MethodGet,
+		URI:    kibanaSettingsURI,
+		Tenant: globalTenant,
+	}
+	clusterRequest.fnSendKibanaRequest(clusterRequest.log, host, payload)
+	if err := kibanaAPIError(payload); err != nil {
+		return kverrors.Wrap(err, "failed to get kibana settings")
+	}
+
+	res := settingsResponse{}
+	if err := json.Unmarshal([]byte(payload.RawResponseBody), &res); err != nil {
+		return kverrors.Wrap(err, "failed to parse kibana settings response")
+	}
+	if current, ok := res.Settings[defaultIndexSettingID]; ok && current.UserValue == defaultIndex {
+		return nil
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"changes": map[string]interface{}{
+			defaultIndexSettingID: defaultIndex,
+		},
+	})
+	if err != nil {
+		return kverrors.Wrap(err, "failed to marshal kibana settings")
+	}
+
+	payload = &KibanaAPIRequest{
+		Method:      http.MethodPost,
+		URI:         kibanaSettingsURI,
+		RequestBody: string(body),
+		Tenant:      globalTenant,
+	}
+	clusterRequest.fnSendKibanaRequest(clusterRequest.log, host, payload)
+	if err := kibanaAPIError(payload); err != nil {
+		return kverrors.Wrap(err, "failed to set kibana default index", "index_pattern", defaultIndex)
+	}
+
+	return nil
+}
+
+func kibanaAPIError(payload *KibanaAPIRequest) error {
+	if payload.Error != nil {
+		return payload.Error
+	}
+	if payload.StatusCode != http.StatusOK {
+		return kverrors.New("unexpected response from kibana",
+			"uri", payload.URI,
+			"status", payload.StatusCode,
+			"response", payload.RawResponseBody,
+		)
+	}
+	return nil
+}
+
+// sendKibanaRequest sends the request directly to the Kibana container, bypassing the oauth proxy.
+// The operator authenticates with its service account token, which Kibana forwards to Elasticsearch.
+func sendKibanaRequest(log logr.Logger, host string, payload *KibanaAPIRequest) {
+	u := fmt.Sprintf("http://%s%s", host, payload.URI)
+
+	var body io.Reader
+	if payload.RequestBody != "" {
+		body = bytes.NewReader([]byte(payload.RequestBody))
+	}
+
+	request, err := http.NewRequest(payload.Method, u, body)
+	if err != nil {
+		payload.Error = err
+		return
+	}
+	request.Header.Set("kbn-xsrf", "true")
+	if payload.ContentType != "" {
+		request.Header.Set("Content-Type", payload.ContentType)
+	} else if payload.RequestBody != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if payload.Tenant != "" {
+		request.Header.Set("securitytenant", payload.Tenant)
+	}
+	if token, err := os.ReadFile(kibanaSAToken); err == nil && len(token) > 0 {
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", bytes.TrimSpace(token)))
+	} else {
+		log.Info("Unable to read auth token for the kibana API", "file", kibanaSAToken)
+	}
+
+	httpClient := &http.Client{Timeout: kibanaAPITimeout}
+	resp, err := httpClient.Do(request)
+	if err != nil {
+		payload.Error = err
+		return
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		payload.Error = err
+		return
+	}
+
+	payload.StatusCode = resp.StatusCode
+	payload.RawResponseBody = string(raw)
+}
@@ -0,0 +1,130 @@
+package kibana
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kibana "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestProvisionSavedObjects(t *testing.T) {
+	cluster := &kibana.Kibana{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kibana",
+			Namespace: "openshift-logging",
+		},
+		Spec: kibana.KibanaSpec{
+			IndexPatterns: []kibana.KibanaIndexPattern{
+				{Title: "app-*"},
+				{Title: "infra-*", TimeFieldName: "timestamp"},
+			},
+			DefaultIndex: "app-*",
+		},
+	}
+	dpl := &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "kibana", Namespace: "openshift-logging", Generation: 2},
+		Spec:       apps.DeploymentSpec{Replicas: pointer.Int32(1)},
+		Status: apps.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  1,
+		},
+	}
+	kibanaPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kibana-1",
+			Namespace: "openshift-logging",
+			Labels:    map[string]string{"component": "kibana"},
+		},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			PodIP:             "10.0.0.1",
+			ContainerStatuses: []v1.ContainerStatus{{Ready: true}},
+		},
+	}
+
+	tests := []struct {
+		desc         string
+		rolledOut    bool
+		defaultIndex string
+		wantRequests []string
+	}{
+		{
+			desc:         "rollout in progress",
+			rolledOut:    false,
+			wantRequests: nil,
+		},
+		{
+			desc:         "default index to set",
+			rolledOut:    true,
+			defaultIndex: "infra-*",
+			wantRequests: []string{
+				"POST 10.0.0.1:5601/api/saved_objects/_bulk_create",
+				"GET 10.0.0.1:5601/api/kibana/settings",
+				"POST 10.0.0.1:5601/api/kibana/settings",
+			},
+		},
+		{
+			desc:         "default index already set",
+			rolledOut:    true,
+			defaultIndex: "app-*",
+			wantRequests: []string{
+				"POST 10.0.0.1:5601/api/saved_objects/_bulk_create",
+				"GET 10.0.0.1:5601/api/kibana/settings",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			d := dpl.DeepCopy()
+			if !test.rolledOut {
+				d.Status.UpdatedReplicas = 0
+			}
+
+			var requests []string
+			clusterRequest := &KibanaRequest{
+				client:  fake.NewFakeClient(d, kibanaPod),
+				cluster: cluster,
+				log:     log.Log,
+				fnSendKibanaRequest: func(_ logr.Logger, host string, payload *KibanaAPIRequest) {
+					requests = append(requests, payload.Method+" "+host+payload.URI)
+					if payload.Tenant != globalTenant {
+						t.Errorf("expected the request to target the global tenant, got %q", payload.Tenant)
+					}
+					payload.StatusCode = http.StatusOK
+					switch {
+					case payload.URI == savedObjectsBulkURI:
+						payload.RawResponseBody = `{"saved_objects":[{"type":"index-pattern","id":"app-*","error":{"statusCode":409,"message":"conflict"}},{"type":"index-pattern","id":"infra-*"}]}`
+					case payload.Method == http.MethodGet:
+						payload.RawResponseBody = `{"settings":{"defaultIndex":{"userValue":"` + test.defaultIndex + `"}}}`
+					default:
+						payload.RawResponseBody = `{}`
+					}
+				},
+			}
+
+			if err := clusterRequest.provisionSavedObjects(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(requests) != len(test.wantRequests) {
+				t.Fatalf("expected requests %v, got %v", test.wantRequests, requests)
+			}
+			for i := range requests {
+				if requests[i] != test.wantRequests[i] {
+					t.Errorf("expected requests %v, got %v", test.wantRequests, requests)
+				}
+			}
+		})
+	}
+}
@@ -2,51 +2,226 @@ package kibana
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	kibana "github.com/openshift/elasticsearch-operator/apis/logging/v1"
 	"github.com/openshift/elasticsearch-operator/internal/manifests/deployment"
+	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (clusterRequest *KibanaRequest) getKibanaStatus() ([]kibana.KibanaStatus, error) {
-	var status []kibana.KibanaStatus
+const (
+	reasonAsExpected                 = "AsExpected"
+	reasonInvalidConfig              = "InvalidConfig"
+	reasonPodsFailing                = "PodsFailing"
+	reasonElasticsearchRefUnresolved = "ElasticsearchReferenceUnresolved"
+	reasonReplicasAvailable          = "MinimumReplicasAvailable"
+	reasonNoReplicasAvailable        = "NoReplicasAvailable"
+	reasonRollingOut                 = "RollingOut"
+	reasonRolloutComplete            = "RolloutComplete"
+	reasonDeploymentNotFound         = "DeploymentNotFound"
+	reasonStatusUnavailable          = "StatusUnavailable"
+	reasonStatusGreen                = "StatusGreen"
+	reasonStatusYellow               = "StatusYellow"
+	reasonStatusRed                  = "StatusRed"
+	reasonStatusUninitialized        = "StatusUninitialized"
+)
+
+// getKibanaStatus returns the observed state of the Kibana deployment and its pods. The conditions
+// are set over the current ones so that their transition time only changes with their status.
+func (clusterRequest *KibanaRequest) getKibanaStatus() (kibana.KibanaStatus, error) {
+	cluster := clusterRequest.cluster
+	status := kibana.KibanaStatus{
+		ObservedGeneration: cluster.Generation,
+	}
+	for _, c := range cluster.Status.Conditions {
+		status.Conditions = append(status.Conditions, *c.DeepCopy())
+	}
+
 	selector := map[string]string{
 		"logging-infra": "kibana",
 	}
 
-	kibanaDeploymentList, err := deployment.List(context.TODO(), clusterRequest.client, clusterRequest.cluster.Namespace, selector)
+	kibanaDeploymentList, err := deployment.List(context.TODO(), clusterRequest.client, cluster.Namespace, selector)
 	if err != nil {
 		return status, err
 	}
 
-	for _, dpl := range kibanaDeploymentList {
-		kibanaStatus := kibana.KibanaStatus{
-			Deployment: dpl.Name,
-			Replicas:   *dpl.Spec.Replicas,
-		}
+	// there should only ever be a single kibana deployment
+	var dpl *apps.Deployment
+	if len(kibanaDeploymentList) > 0 {
+		dpl = &kibanaDeploymentList[0]
+
+		status.Deployment = dpl.Name
+		status.Replicas = *dpl.Spec.Replicas
+		status.ReadyReplicas = dpl.Status.ReadyReplicas
 
 		replicaSetList, _ := deployment.ListReplicaSets(context.TODO(), clusterRequest.client, dpl.Name, dpl.Namespace, selector)
 		var replicaNames []string
 		for _, replicaSet := range replicaSetList {
 			replicaNames = append(replicaNames, replicaSet.Name)
 		}
-		kibanaStatus.ReplicaSets = replicaNames
+		status.ReplicaSets = replicaNames
 
 		podList, _ := deployment.ListPods(context.TODO(), clusterRequest.client, dpl.Name, dpl.Namespace, selector)
-		kibanaStatus.Pods = podStateMap(podList)
+		status.Pods = podStateMap(podList)
+	}
 
-		kibanaStatus.Conditions, err = clusterRequest.getPodConditions("kibana")
-		if err != nil {
-			return nil, err
-		}
+	status.Autoscaling = clusterRequest.getAutoscalingStatus()
+	status.SavedObjects = clusterRequest.getSavedObjectsStatus()
+
+	status.PodConditions, err = clusterRequest.getPodConditions("kibana")
+	if err != nil {
+		return status, err
+	}
+
+	if clusterRequest.onKubernetes() {
+		status.URL = clusterRequest.getIngressURL()
+	} else if url, err := clusterRequest.GetRouteURL("kibana"); err == nil {
+		status.URL = url
+	}
 
-		status = append(status, kibanaStatus)
+	setAvailableCondition(&status, clusterRequest.health)
+	setProgressingCondition(&status, dpl)
+	specErr := configPolicy.Validate(cluster.Spec.Config)
+	if specErr == nil {
+		specErr = validateRouteSpec(cluster.Spec.Route)
 	}
+	setDegradedCondition(&status, specErr, clusterRequest.health)
+	setHealthyCondition(&status, clusterRequest.health)
 
 	return status, nil
 }
 
+func setAvailableCondition(status *kibana.KibanaStatus, health *kibanaHealth) {
+	condition := metav1.Condition{
+		Type:               kibana.KibanaAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonReplicasAvailable,
+		Message:            fmt.Sprintf("%d kibana pods are ready", status.ReadyReplicas),
+		ObservedGeneration: status.ObservedGeneration,
+	}
+	switch {
+	case status.ReadyReplicas == 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonNoReplicasAvailable
+		condition.Message = "no kibana pod is ready"
+	case isRed(health):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonStatusRed
+		condition.Message = fmt.Sprintf("%d kibana pods are ready but kibana status is red", status.ReadyReplicas)
+	}
+
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+func setProgressingCondition(status *kibana.KibanaStatus, dpl *apps.Deployment) {
+	condition := metav1.Condition{
+		Type:               kibana.KibanaProgressing,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonRolloutComplete,
+		Message:            "all kibana pods run the latest deployment",
+		ObservedGeneration: status.ObservedGeneration,
+	}
+
+	switch {
+	case dpl == nil:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonDeploymentNotFound
+		condition.Message = "the kibana deployment is not created yet"
+	case !isRolledOut(dpl):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonRollingOut
+		condition.Message = fmt.Sprintf("%d of %d kibana pods run the latest deployment", dpl.Status.UpdatedReplicas, status.Replicas)
+	}
+
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+func setDegradedCondition(status *kibana.KibanaStatus, configErr error, health *kibanaHealth) {
+	condition := metav1.Condition{
+		Type:               kibana.KibanaDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonAsExpected,
+		ObservedGeneration: status.ObservedGeneration,
+	}
+
+	switch {
+	case configErr != nil:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonInvalidConfig
+		condition.Message = configErr.Error()
+	case len(status.PodConditions) > 0:
+		pods := make([]string, 0, len(status.PodConditions))
+		for name := range status.PodConditions {
+			pods = append(pods, name)
+		}
+		sort.Strings(pods)
+
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonPodsFailing
+		condition.Message = fmt.Sprintf("kibana pods are failing: %s", strings.Join(pods, ", "))
+	case isRed(health):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonStatusRed
+		condition.Message = fmt.Sprintf("kibana plugins are failing: %s", strings.Join(health.Failures, "; "))
+	}
+
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// setHealthyCondition reports the overall state of Kibana and the states of the plugins which are
+// not green. The condition is left untouched when the status API was not queried.
+func setHealthyCondition(status *kibana.KibanaStatus, health *kibanaHealth) {
+	if health == nil {
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               kibana.KibanaHealthy,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: status.ObservedGeneration,
+	}
+
+	if health.Error != nil {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = reasonStatusUnavailable
+		condition.Message = fmt.Sprintf("unable to query the kibana status: %s", health.Error)
+		meta.SetStatusCondition(&status.Conditions, condition)
+		return
+	}
+
+	switch health.State {
+	case kibanaStateGreen:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonStatusGreen
+	case kibanaStateYellow:
+		condition.Reason = reasonStatusYellow
+	case kibanaStateRed:
+		condition.Reason = reasonStatusRed
+	default:
+		condition.Reason = reasonStatusUninitialized
+	}
+
+	condition.Message = fmt.Sprintf("kibana status is %s", health.State)
+	if len(health.Failures) > 0 {
+		condition.Message = fmt.Sprintf("%s: %s", condition.Message, strings.Join(health.Failures, "; "))
+	}
+
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+func isRed(health *kibanaHealth) bool {
+	return health != nil && health.Error == nil && health.State == kibanaStateRed
+}
+
 func podStateMap(podList []corev1.Pod) kibana.PodStateMap {
 	stateMap := map[kibana.PodStateType][]string{
 		kibana.PodStateTypeReady:    {},
@@ -149,3 +324,42 @@ func (clusterRequest *KibanaRequest) getPodConditions(component string) (map[str
 
 	return podConditions, nil
 }
+
+// UpdateElasticsearchRefStatus reports on the status of the Kibana CR that its Elasticsearch
+// cluster cannot be resolved. The condition is cleared by the next status update once resolved.
+func UpdateElasticsearchRefStatus(c client.Client, cluster *kibana.Kibana, message string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current := &kibana.Kibana{}
+		if err := c.Get(context.TODO(), client.ObjectKeyFromObject(cluster), current); err != nil {
+			return err
+		}
+
+		status := current.Status.DeepCopy()
+		status.ObservedGeneration = current.Generation
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               kibana.KibanaDegraded,
+			Status:             metav1.ConditionTrue,
+			Reason:             reasonElasticsearchRefUnresolved,
+			Message:            message,
+			ObservedGeneration: current.Generation,
+		})
+
+		if equality.Semantic.DeepEqual(*status, current.Status) {
+			return nil
+		}
+
+		current.Status = *status
+		return c.Status().Update(context.TODO(), current)
+	})
+}
+
+// MigrateLegacyStatus stores the status of a Kibana CR, which still holds the former list of statuses,
+// as an object. The list is converted when decoding the CR and its status never carries the observed
+// generation set by every status update of the operator.
+func MigrateLegacyStatus(c client.Client, cluster *kibana.Kibana) error {
+	if cluster.Status.ObservedGeneration != 0 {
+		return nil
+	}
+
+	return c.Status().Update(context.TODO(), cluster)
+}
@@ -0,0 +1,254 @@
+package kibana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	loggingv1 "github.com/openshift/elasticsearch-operator/apis/logging/v1"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestGetKibanaStatus(t *testing.T) {
+	dpl := &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "kibana",
+			Namespace:  "openshift-logging",
+			Generation: 3,
+			Labels:     map[string]string{"logging-infra": "kibana"},
+		},
+		Spec: apps.DeploymentSpec{Replicas: pointer.Int32(2)},
+		Status: apps.DeploymentStatus{
+			ObservedGeneration: 3,
+			Replicas:           2,
+			UpdatedReplicas:    2,
+			ReadyReplicas:      2,
+			AvailableReplicas:  2,
+		},
+	}
+
+	tests := []struct {
+		desc            string
+		config          map[string]string
+		updatedReplicas int32
+		readyReplicas   int32
+		health          *kibanaHealth
+		wantAvailable   metav1.ConditionStatus
+		wantProgressing metav1.ConditionStatus
+		wantDegraded    string
+		wantHealthy     metav1.ConditionStatus
+	}{
+		{
+			desc:            "rolled out",
+			updatedReplicas: 2,
+			readyReplicas:   2,
+			wantAvailable:   metav1.ConditionTrue,
+			wantProgressing: metav1.ConditionFalse,
+			wantDegraded:    reasonAsExpected,
+		},
+		{
+			desc:            "rollout in progress",
+			updatedReplicas: 1,
+			readyReplicas:   0,
+			wantAvailable:   metav1.ConditionFalse,
+			wantProgressing: metav1.ConditionTrue,
+			wantDegraded:    reasonAsExpected,
+		},
+		{
+			desc:            "invalid config",
+			config:          map[string]string{"server.port": "8080"},
+			updatedReplicas: 2,
+			readyReplicas:   2,
+			wantAvailable:   metav1.ConditionTrue,
+			wantProgressing: metav1.ConditionFalse,
+			wantDegraded:    reasonInvalidConfig,
+		},
+		{
+			desc:            "kibana status green",
+			updatedReplicas: 2,
+			readyReplicas:   2,
+			health:          &kibanaHealth{State: kibanaStateGreen},
+			wantAvailable:   metav1.ConditionTrue,
+			wantProgressing: metav1.ConditionFalse,
+			wantDegraded:    reasonAsExpected,
+			wantHealthy:     metav1.ConditionTrue,
+		},
+		{
+			desc:            "kibana status red",
+			updatedReplicas: 2,
+			readyReplicas:   2,
+			health: &kibanaHealth{
+				State:    kibanaStateRed,
+				Failures: []string{"plugin:elasticsearch@6.8.1 red: Migration of the .kibana index failed"},
+			},
+			wantAvailable:   metav1.ConditionFalse,
+			wantProgressing: metav1.ConditionFalse,
+			wantDegraded:    reasonStatusRed,
+			wantHealthy:     metav1.ConditionFalse,
+		},
+		{
+			desc:            "kibana status unavailable",
+			updatedReplicas: 2,
+			readyReplicas:   2,
+			health:          &kibanaHealth{Error: errors.New("connection refused")},
+			wantAvailable:   metav1.ConditionTrue,
+			wantProgressing: metav1.ConditionFalse,
+			wantDegraded:    reasonAsExpected,
+			wantHealthy:     metav1.ConditionUnknown,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			d := dpl.DeepCopy()
+			d.Status.UpdatedReplicas = test.updatedReplicas
+			d.Status.ReadyReplicas = test.readyReplicas
+
+			clusterRequest := &KibanaRequest{
+				client: fake.NewFakeClient(d),
+				cluster: &loggingv1.Kibana{
+					ObjectMeta: metav1.ObjectMeta{Name: "kibana", Namespace: "openshift-logging", Generation: 5},
+					Spec:       loggingv1.KibanaSpec{Config: test.config},
+				},
+				log:    log.Log,
+				health: test.health,
+			}
+
+			status, err := clusterRequest.getKibanaStatus()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if status.ObservedGeneration != 5 || status.ReadyReplicas != test.readyReplicas || status.Deployment != "kibana" {
+				t.Errorf("unexpected status %v", status)
+			}
+
+			if c := meta.FindStatusCondition(status.Conditions, loggingv1.KibanaAvailable); c == nil || c.Status != test.wantAvailable {
+				t.Errorf("expected available condition %q, got %v", test.wantAvailable, c)
+			}
+			if c := meta.FindStatusCondition(status.Conditions, loggingv1.KibanaProgressing); c == nil || c.Status != test.wantProgressing {
+				t.Errorf("expected progressing condition %q, got %v", test.wantProgressing, c)
+			}
+			if c := meta.FindStatusCondition(status.Conditions, loggingv1.KibanaDegraded); c == nil || c.Reason != test.wantDegraded {
+				t.Errorf("expected degraded condition reason %q, got %v", test.wantDegraded, c)
+			}
+			c := meta.FindStatusCondition(status.Conditions, loggingv1.KibanaHealthy)
+			if (c == nil && test.wantHealthy != "") || (c != nil && c.Status != test.wantHealthy) {
+				t.Errorf("expected healthy condition %q, got %v", test.wantHealthy, c)
+			}
+		})
+	}
+}
+
+func TestUpdateElasticsearchRefStatus(t *testing.T) {
+	utilruntime.Must(loggingv1.AddToScheme(scheme.Scheme))
+
+	cluster := &loggingv1.Kibana{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kibana",
+			Namespace: "openshift-logging",
+		},
+		Spec: loggingv1.KibanaSpec{
+			ElasticsearchRef: &loggingv1.ElasticsearchReference{Name: "elasticsearch-app"},
+		},
+		Status: loggingv1.KibanaStatus{
+			ReadyReplicas: 1,
+			Conditions: []metav1.Condition{
+				{Type: loggingv1.KibanaAvailable, Status: metav1.ConditionTrue, Reason: reasonReplicasAvailable},
+				{Type: loggingv1.KibanaDegraded, Status: metav1.ConditionFalse, Reason: reasonAsExpected},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cluster).Build()
+
+	if err := UpdateElasticsearchRefStatus(k8sClient, cluster, "not found"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got := &loggingv1.Kibana{}
+	if err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), got); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.Status.ReadyReplicas != 1 || !meta.IsStatusConditionTrue(got.Status.Conditions, loggingv1.KibanaAvailable) {
+		t.Errorf("expected the observed state to be kept, got %v", got.Status)
+	}
+
+	c := meta.FindStatusCondition(got.Status.Conditions, loggingv1.KibanaDegraded)
+	if c == nil || c.Status != metav1.ConditionTrue || c.Reason != reasonElasticsearchRefUnresolved || c.Message != "not found" {
+		t.Errorf("expected an unresolved reference condition, got %v", c)
+	}
+}
+
+func TestKibanaStatusFromLegacyList(t *testing.T) {
+	legacy := `{"status":[{"replicas":2,"deployment":"kibana","replicaSets":["kibana-5d8"],"pods":{"ready":["kibana-5d8-a"]},"clusterCondition":{"kibana-5d8-b":[{"type":"Unschedulable","status":"True"}]}}]}`
+
+	k := &loggingv1.Kibana{}
+	if err := json.Unmarshal([]byte(legacy), k); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if k.Status.Deployment != "kibana" || k.Status.Replicas != 2 || k.Status.ReplicaSets[0] != "kibana-5d8" {
+		t.Errorf("expected the legacy status to be converted, got %v", k.Status)
+	}
+	if got := k.Status.Pods[loggingv1.PodStateTypeReady]; len(got) != 1 || got[0] != "kibana-5d8-a" {
+		t.Errorf("expected the pods of the legacy status, got %v", k.Status.Pods)
+	}
+	if _, ok := k.Status.PodConditions["kibana-5d8-b"]; !ok {
+		t.Errorf("expected the pod conditions of the legacy status, got %v", k.Status.PodConditions)
+	}
+
+	current := `{"status":{"readyReplicas":1,"conditions":[{"type":"Available","status":"True","reason":"MinimumReplicasAvailable","message":"","lastTransitionTime":null}]}}`
+	k = &loggingv1.Kibana{}
+	if err := json.Unmarshal([]byte(current), k); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if k.Status.ReadyReplicas != 1 || !meta.IsStatusConditionTrue(k.Status.Conditions, loggingv1.KibanaAvailable) {
+		t.Errorf("expected the status to be decoded, got %v", k.Status)
+	}
+}
+
+func TestMigrateLegacyStatus(t *testing.T) {
+	utilruntime.Must(loggingv1.AddToScheme(scheme.Scheme))
+
+	stored := &unstructured.Unstructured{}
+	stored.SetGroupVersionKind(loggingv1.GroupVersion.WithKind("Kibana"))
+	stored.SetName("kibana")
+	stored.SetNamespace("openshift-logging")
+	stored.Object["status"] = []interface{}{
+		map[string]interface{}{"replicas": int64(1), "deployment": "kibana"},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(stored).Build()
+
+	cluster := &loggingv1.Kibana{}
+	if err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(stored), cluster); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := MigrateLegacyStatus(k8sClient, cluster); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	migrated := &unstructured.Unstructured{}
+	migrated.SetGroupVersionKind(loggingv1.GroupVersion.WithKind("Kibana"))
+	if err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(stored), migrated); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	status, ok := migrated.Object["status"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected the status to be stored as an object, got %v", migrated.Object["status"])
+	}
+	if status["deployment"] != "kibana" {
+		t.Errorf("expected the legacy status to be kept, got %v", status)
+	}
+}
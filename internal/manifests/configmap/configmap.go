@@ -36,6 +36,22 @@ func Get(ctx context.Context, c client.Client, key client.ObjectKey) (*corev1.Co
 	return cm, nil
 }
 
+// List returns a list of configmaps that match the given selector.
+func List(ctx context.Context, c client.Client, namespace string, selector map[string]string) ([]corev1.ConfigMap, error) {
+	list := &corev1.ConfigMapList{}
+	opts := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels(selector),
+	}
+	if err := c.List(ctx, list, opts...); err != nil {
+		return nil, kverrors.Wrap(err, "failed to list configmaps",
+			"namespace", namespace,
+		)
+	}
+
+	return list.Items, nil
+}
+
 // GetDataSHA256 returns the sha256 checksum of the confimap data keys
 func GetDataSHA256(ctx context.Context, c client.Client, key client.ObjectKey, excludeKeys []string) string {
 	hash := ""
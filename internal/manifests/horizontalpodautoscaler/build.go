@@ -0,0 +1,67 @@
+package horizontalpodautoscaler
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Builder represents the struct to build k8s horizontalpodautoscalers
+type Builder struct {
+	hpa *autoscalingv2.HorizontalPodAutoscaler
+}
+
+// New returns a new Builder instance with a default initialized horizontalpodautoscaler.
+func New(name, namespace string, labels map[string]string) *Builder {
+	return &Builder{hpa: newHorizontalPodAutoscaler(name, namespace, labels)}
+}
+
+func newHorizontalPodAutoscaler(name, namespace string, labels map[string]string) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: autoscalingv2.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{},
+	}
+}
+
+// Build returns the final horizontalpodautoscaler.
+func (b *Builder) Build() *autoscalingv2.HorizontalPodAutoscaler { return b.hpa }
+
+// WithScaleTarget sets the deployment scaled by the autoscaler.
+func (b *Builder) WithScaleTarget(deploymentName string) *Builder {
+	b.hpa.Spec.ScaleTargetRef = autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       deploymentName,
+	}
+	return b
+}
+
+// WithReplicas sets the bounds of the number of replicas of the scale target.
+func (b *Builder) WithReplicas(min *int32, max int32) *Builder {
+	b.hpa.Spec.MinReplicas = min
+	b.hpa.Spec.MaxReplicas = max
+	return b
+}
+
+// WithResourceUtilization adds a target average utilization in percent of the requests of the resource.
+func (b *Builder) WithResourceUtilization(name corev1.ResourceName, percentage int32) *Builder {
+	b.hpa.Spec.Metrics = append(b.hpa.Spec.Metrics, autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &percentage,
+			},
+		},
+	})
+	return b
+}
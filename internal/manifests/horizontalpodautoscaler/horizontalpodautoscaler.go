@@ -0,0 +1,126 @@
+package horizontalpodautoscaler
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EqualityFunc is the type for functions that compare two horizontalpodautoscalers.
+// Return true if two horizontalpodautoscalers are equal.
+type EqualityFunc func(current, desired *autoscalingv2.HorizontalPodAutoscaler) bool
+
+// MutateFunc is the type for functions that mutate the current horizontalpodautoscaler
+// by applying the values from the desired horizontalpodautoscaler.
+type MutateFunc func(current, desired *autoscalingv2.HorizontalPodAutoscaler)
+
+// CreateOrUpdate attempts first to get the given horizontalpodautoscaler. If the
+// horizontalpodautoscaler does not exist, the horizontalpodautoscaler will be created. Otherwise,
+// if the horizontalpodautoscaler exists and the provided comparison func detects any changes
+// an update is attempted. Updates are retried with backoff (See retry.DefaultRetry).
+// Returns on failure an non-nil error.
+func CreateOrUpdate(ctx context.Context, c client.Client, hpa *autoscalingv2.HorizontalPodAutoscaler, equal EqualityFunc, mutate MutateFunc) error {
+	current := &autoscalingv2.HorizontalPodAutoscaler{}
+	key := client.ObjectKey{Name: hpa.Name, Namespace: hpa.Namespace}
+	err := c.Get(ctx, key, current)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			err = c.Create(ctx, hpa)
+
+			if err == nil {
+				return nil
+			}
+
+			return kverrors.Wrap(err, "failed to create horizontalpodautoscaler",
+				"name", hpa.Name,
+				"namespace", hpa.Namespace,
+			)
+		}
+
+		return kverrors.Wrap(err, "failed to get horizontalpodautoscaler",
+			"name", hpa.Name,
+			"namespace", hpa.Namespace,
+		)
+	}
+
+	if !equal(current, hpa) {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := c.Get(ctx, key, current); err != nil {
+				return kverrors.Wrap(err, "failed to get horizontalpodautoscaler",
+					"name", hpa.Name,
+					"namespace", hpa.Namespace,
+				)
+			}
+
+			mutate(current, hpa)
+			if err := c.Update(ctx, current); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return kverrors.Wrap(err, "failed to update horizontalpodautoscaler",
+				"name", hpa.Name,
+				"namespace", hpa.Namespace,
+			)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// Delete attempts to delete a k8s horizontalpodautoscaler if existing or returns an error.
+// A horizontalpodautoscaler that does not exist is not considered an error.
+func Delete(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	hpa := New(key.Name, key.Namespace, nil).Build()
+
+	if err := c.Delete(ctx, hpa, &client.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return kverrors.Wrap(err, "failed to delete horizontalpodautoscaler",
+			"name", hpa.Name,
+			"namespace", hpa.Namespace,
+		)
+	}
+
+	return nil
+}
+
+// Get returns the k8s horizontalpodautoscaler for the given object key or an error.
+func Get(ctx context.Context, c client.Client, key client.ObjectKey) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpa := New(key.Name, key.Namespace, nil).Build()
+
+	if err := c.Get(ctx, key, hpa); err != nil {
+		return hpa, kverrors.Wrap(err, "failed to get horizontalpodautoscaler",
+			"name", hpa.Name,
+			"namespace", hpa.Namespace,
+		)
+	}
+
+	return hpa, nil
+}
+
+// Equal returns true only if the labels, the scale target, the replica bounds and the metrics
+// of the horizontalpodautoscalers are equal. The scaling behavior defaulted by the api server
+// is not compared.
+func Equal(current, desired *autoscalingv2.HorizontalPodAutoscaler) bool {
+	return equality.Semantic.DeepEqual(current.Labels, desired.Labels) &&
+		equality.Semantic.DeepEqual(current.Spec.ScaleTargetRef, desired.Spec.ScaleTargetRef) &&
+		equality.Semantic.DeepEqual(current.Spec.MinReplicas, desired.Spec.MinReplicas) &&
+		current.Spec.MaxReplicas == desired.Spec.MaxReplicas &&
+		equality.Semantic.DeepEqual(current.Spec.Metrics, desired.Spec.Metrics)
+}
+
+// Mutate is a default mutation function for horizontalpodautoscalers
+// that copies only mutable fields from desired to current.
+func Mutate(current, desired *autoscalingv2.HorizontalPodAutoscaler) {
+	current.Labels = desired.Labels
+	current.Spec.ScaleTargetRef = desired.Spec.ScaleTargetRef
+	current.Spec.MinReplicas = desired.Spec.MinReplicas
+	current.Spec.MaxReplicas = desired.Spec.MaxReplicas
+	current.Spec.Metrics = desired.Spec.Metrics
+}
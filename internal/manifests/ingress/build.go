@@ -0,0 +1,83 @@
+package ingress
+
+import (
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Builder represents the struct to build k8s ingresses
+type Builder struct {
+	ing *networking.Ingress
+}
+
+// New returns a new Builder instance with a default initialized ingress.
+func New(name, namespace string, labels map[string]string) *Builder {
+	return &Builder{ing: newIngress(name, namespace, labels)}
+}
+
+func newIngress(name, namespace string, labels map[string]string) *networking.Ingress {
+	return &networking.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: networking.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+	}
+}
+
+// Build returns the final ingress.
+func (b *Builder) Build() *networking.Ingress { return b.ing }
+
+// WithAnnotations sets the annotations of the ingress.
+func (b *Builder) WithAnnotations(a map[string]string) *Builder {
+	b.ing.Annotations = a
+	return b
+}
+
+// WithIngressClassName sets the name of the IngressClass of the ingress.
+func (b *Builder) WithIngressClassName(name *string) *Builder {
+	b.ing.Spec.IngressClassName = name
+	return b
+}
+
+// WithServiceBackend routes all paths of the host to the given service port.
+func (b *Builder) WithServiceBackend(host, serviceName string, port int32) *Builder {
+	pathType := networking.PathTypePrefix
+	b.ing.Spec.Rules = []networking.IngressRule{
+		{
+			Host: host,
+			IngressRuleValue: networking.IngressRuleValue{
+				HTTP: &networking.HTTPIngressRuleValue{
+					Paths: []networking.HTTPIngressPath{
+						{
+							Path:     "/",
+							PathType: &pathType,
+							Backend: networking.IngressBackend{
+								Service: &networking.IngressServiceBackend{
+									Name: serviceName,
+									Port: networking.ServiceBackendPort{Number: port},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return b
+}
+
+// WithTLS sets the secret with the certificate presented for the given hosts.
+func (b *Builder) WithTLS(secretName string, hosts ...string) *Builder {
+	b.ing.Spec.TLS = []networking.IngressTLS{
+		{
+			Hosts:      hosts,
+			SecretName: secretName,
+		},
+	}
+	return b
+}
@@ -0,0 +1,106 @@
+package ingress
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EqualityFunc is the type for functions that compare two ingresses.
+// Return true if two ingresses are equal.
+type EqualityFunc func(current, desired *networking.Ingress) bool
+
+// MutateFunc is the type for functions that mutate the current ingress
+// by applying the values from the desired ingress.
+type MutateFunc func(current, desired *networking.Ingress)
+
+// CreateOrUpdate attempts first to get the given ingress. If the
+// ingress does not exist, the ingress will be created. Otherwise,
+// if the ingress exists and the provided comparison func detects any changes
+// an update is attempted. Updates are retried with backoff (See retry.DefaultRetry).
+// Returns on failure an non-nil error.
+func CreateOrUpdate(ctx context.Context, c client.Client, ing *networking.Ingress, equal EqualityFunc, mutate MutateFunc) error {
+	current := &networking.Ingress{}
+	key := client.ObjectKey{Name: ing.Name, Namespace: ing.Namespace}
+	err := c.Get(ctx, key, current)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			err = c.Create(ctx, ing)
+
+			if err == nil {
+				return nil
+			}
+
+			return kverrors.Wrap(err, "failed to create ingress",
+				"name", ing.Name,
+				"namespace", ing.Namespace,
+			)
+		}
+
+		return kverrors.Wrap(err, "failed to get ingress",
+			"name", ing.Name,
+			"namespace", ing.Namespace,
+		)
+	}
+
+	if !equal(current, ing) {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := c.Get(ctx, key, current); err != nil {
+				return kverrors.Wrap(err, "failed to get ingress",
+					"name", ing.Name,
+					"namespace", ing.Namespace,
+				)
+			}
+
+			mutate(current, ing)
+			if err := c.Update(ctx, current); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return kverrors.Wrap(err, "failed to update ingress",
+				"name", ing.Name,
+				"namespace", ing.Namespace,
+			)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// Delete attempts to delete a k8s ingress if existing or returns an error.
+// A ingress that does not exist is not considered an error.
+func Delete(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	ing := New(key.Name, key.Namespace, nil).Build()
+
+	if err := c.Delete(ctx, ing, &client.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return kverrors.Wrap(err, "failed to delete ingress",
+			"name", ing.Name,
+			"namespace", ing.Namespace,
+		)
+	}
+
+	return nil
+}
+
+// Equal returns true only if the labels, the annotations and the spec of the ingresses are equal.
+func Equal(current, desired *networking.Ingress) bool {
+	return equality.Semantic.DeepEqual(current.Labels, desired.Labels) &&
+		equality.Semantic.DeepEqual(current.Annotations, desired.Annotations) &&
+		equality.Semantic.DeepEqual(current.Spec, desired.Spec)
+}
+
+// Mutate is a default mutation function for ingresses
+// that copies only mutable fields from desired to current.
+func Mutate(current, desired *networking.Ingress) {
+	current.Labels = desired.Labels
+	current.Annotations = desired.Annotations
+	current.Spec = desired.Spec
+}
@@ -0,0 +1,48 @@
+package networkpolicy
+
+import (
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Builder represents the struct to build k8s networkpolicies
+type Builder struct {
+	np *networking.NetworkPolicy
+}
+
+// New returns a new Builder instance with a default initialized networkpolicy.
+func New(name, namespace string, labels map[string]string) *Builder {
+	return &Builder{np: newNetworkPolicy(name, namespace, labels)}
+}
+
+func newNetworkPolicy(name, namespace string, labels map[string]string) *networking.NetworkPolicy {
+	return &networking.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: networking.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: networking.NetworkPolicySpec{
+			PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress},
+		},
+	}
+}
+
+// Build returns the final networkpolicy.
+func (b *Builder) Build() *networking.NetworkPolicy { return b.np }
+
+// WithPodSelector sets the label selector of the pods the policy applies to.
+func (b *Builder) WithPodSelector(s map[string]string) *Builder {
+	b.np.Spec.PodSelector = metav1.LabelSelector{MatchLabels: s}
+	return b
+}
+
+// WithIngressRules appends ingress rules to the networkpolicy.
+func (b *Builder) WithIngressRules(r ...networking.NetworkPolicyIngressRule) *Builder {
+	b.np.Spec.Ingress = append(b.np.Spec.Ingress, r...)
+	return b
+}
@@ -0,0 +1,106 @@
+package networkpolicy
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EqualityFunc is the type for functions that compare two networkpolicies.
+// Return true if two networkpolicies are equal.
+type EqualityFunc func(current, desired *networking.NetworkPolicy) bool
+
+// MutateFunc is the type for functions that mutate the current networkpolicy
+// by applying the values from the desired networkpolicy.
+type MutateFunc func(current, desired *networking.NetworkPolicy)
+
+// CreateOrUpdate attempts first to get the given networkpolicy. If the
+// networkpolicy does not exist, the networkpolicy will be created. Otherwise,
+// if the networkpolicy exists and the provided comparison func detects any changes
+// an update is attempted. Updates are retried with backoff (See retry.DefaultRetry).
+// Returns on failure an non-nil error.
+func CreateOrUpdate(ctx context.Context, c client.Client, np *networking.NetworkPolicy, equal EqualityFunc, mutate MutateFunc) error {
+	current := &networking.NetworkPolicy{}
+	key := client.ObjectKey{Name: np.Name, Namespace: np.Namespace}
+	err := c.Get(ctx, key, current)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			err = c.Create(ctx, np)
+
+			if err == nil {
+				return nil
+			}
+
+			return kverrors.Wrap(err, "failed to create networkpolicy",
+				"name", np.Name,
+				"namespace", np.Namespace,
+			)
+		}
+
+		return kverrors.Wrap(err, "failed to get networkpolicy",
+			"name", np.Name,
+			"namespace", np.Namespace,
+		)
+	}
+
+	if !equal(current, np) {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := c.Get(ctx, key, current); err != nil {
+				return kverrors.Wrap(err, "failed to get networkpolicy",
+					"name", np.Name,
+					"namespace", np.Namespace,
+				)
+			}
+
+			mutate(current, np)
+			if err := c.Update(ctx, current); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return kverrors.Wrap(err, "failed to update networkpolicy",
+				"name", np.Name,
+				"namespace", np.Namespace,
+			)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// Delete attempts to delete a k8s networkpolicy if existing or returns an error.
+// A networkpolicy that does not exist is not considered an error.
+func Delete(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	np := New(key.Name, key.Namespace, nil).Build()
+
+	if err := c.Delete(ctx, np, &client.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return kverrors.Wrap(err, "failed to delete networkpolicy",
+			"name", np.Name,
+			"namespace", np.Namespace,
+		)
+	}
+
+	return nil
+}
+
+// Equal returns true only if the labels and the spec of the networkpolicies are equal.
+func Equal(current, desired *networking.NetworkPolicy) bool {
+	return equality.Semantic.DeepEqual(current.Labels, desired.Labels) &&
+		equality.Semantic.DeepEqual(current.Spec, desired.Spec)
+}
+
+// Mutate is a default mutation function for networkpolicies
+// that copies only mutable fields from desired to current.
+func Mutate(current, desired *networking.NetworkPolicy) {
+	current.Labels = desired.Labels
+	current.Spec.PodSelector = desired.Spec.PodSelector
+	current.Spec.Ingress = desired.Spec.Ingress
+	current.Spec.PolicyTypes = desired.Spec.PolicyTypes
+}
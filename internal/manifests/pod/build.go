@@ -49,6 +49,24 @@ func (b *Builder) WithAffinity(a *corev1.Affinity) *Builder {
 	return b
 }
 
+// WithPriorityClassName sets the priority class for the podspec
+func (b *Builder) WithPriorityClassName(name string) *Builder {
+	b.spec.PriorityClassName = name
+	return b
+}
+
+// WithTopologySpreadConstraints appends topology spread constraints to the podspec
+func (b *Builder) WithTopologySpreadConstraints(c ...corev1.TopologySpreadConstraint) *Builder {
+	b.spec.TopologySpreadConstraints = append(b.spec.TopologySpreadConstraints, c...)
+	return b
+}
+
+// WithInitContainers appends init containers to the podspec
+func (b *Builder) WithInitContainers(c ...corev1.Container) *Builder {
+	b.spec.InitContainers = append(b.spec.InitContainers, c...)
+	return b
+}
+
 // WithRestartPolicy sets the restart policy for the podspec
 func (b *Builder) WithRestartPolicy(rp corev1.RestartPolicy) *Builder {
 	b.spec.RestartPolicy = rp
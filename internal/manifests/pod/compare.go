@@ -6,27 +6,38 @@ import (
 	"github.com/openshift/elasticsearch-operator/internal/utils/comparators"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 )
 
 // ArePodTemplateSpecEqual compares two corev1.PodTemplateSpec objects
-// and returns true only if pod spec are equal and tolerations are strictly the same
+// and returns true only if pod spec are equal and tolerations are strictly the same.
+// The labels need to be the same and the annotations of rhs need to be found in lhs,
+// as annotations may be added to the template by others (e.g. kubectl rollout restart).
 func ArePodTemplateSpecEqual(lhs, rhs corev1.PodTemplateSpec) bool {
+	if !comparators.AreSelectorsSame(lhs.Labels, rhs.Labels) {
+		return false
+	}
+
+	for key, value := range rhs.Annotations {
+		if lhsValue, ok := lhs.Annotations[key]; !ok || lhsValue != value {
+			return false
+		}
+	}
+
 	return ArePodSpecEqual(lhs.Spec, rhs.Spec, true)
 }
 
 // ArePodSpecEqual compares two corev1.PodSpec objects and returns true
 // only if they are equal in any of the following:
-// - Length of containers slice
+// - Length of containers and init containers slices
 // - Node selectors
 // - Tolerations, if strict they need to be the same, non-strict for superset check
-// - Containers: Name, Image, VolumeMounts, EnvVar, Args, Ports, ResourceRequirements
+// - Priority class name, affinity and topology spread constraints, if non-strict only when set in rhs
+// - Volumes: rhs volume names need to be found in lhs
+// - Containers and init containers: Name, Image, Command, VolumeMounts, EnvVar, Args, Ports, ResourceRequirements
 func ArePodSpecEqual(lhs, rhs corev1.PodSpec, strictTolerations bool) bool {
 	equal := true
 
-	if len(lhs.Containers) != len(rhs.Containers) {
-		equal = false
-	}
-
 	// check nodeselectors
 	if !comparators.AreSelectorsSame(lhs.NodeSelector, rhs.NodeSelector) {
 		equal = false
@@ -47,11 +58,67 @@ func ArePodSpecEqual(lhs, rhs corev1.PodSpec, strictTolerations bool) bool {
 		}
 	}
 
+	// admission may set a default priority class on pods, when not strict the scheduling
+	// fields are only compared when rhs sets them
+	if (strictTolerations || rhs.PriorityClassName != "") && lhs.PriorityClassName != rhs.PriorityClassName {
+		equal = false
+	}
+
+	if (strictTolerations || rhs.Affinity != nil) && !equality.Semantic.DeepEqual(lhs.Affinity, rhs.Affinity) {
+		equal = false
+	}
+
+	if (strictTolerations || len(rhs.TopologySpreadConstraints) > 0) &&
+		!equality.Semantic.DeepEqual(lhs.TopologySpreadConstraints, rhs.TopologySpreadConstraints) {
+		equal = false
+	}
+
+	// can't compare the volume sources, due to k8s defaulting them and adding token volumes
+	// check that the rhs volumes are all found within lhs by name
+	if !containsSameVolumeNames(lhs.Volumes, rhs.Volumes) {
+		equal = false
+	}
+
+	if !areContainersEqual(lhs.Containers, rhs.Containers) {
+		equal = false
+	}
+
+	if !areContainersEqual(lhs.InitContainers, rhs.InitContainers) {
+		equal = false
+	}
+
+	return equal
+}
+
+func containsSameVolumeNames(lhs, rhs []corev1.Volume) bool {
+	for _, rVolume := range rhs {
+		found := false
+
+		for _, lVolume := range lhs {
+			if lVolume.Name == rVolume.Name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func areContainersEqual(lhs, rhs []corev1.Container) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
 	// check container fields
-	for _, lContainer := range lhs.Containers {
+	for _, lContainer := range lhs {
 		found := false
 
-		for _, rContainer := range rhs.Containers {
+		for _, rContainer := range rhs {
 			// Only compare the images of containers with the same name
 			if lContainer.Name != rContainer.Name {
 				continue
@@ -62,34 +129,38 @@ func ArePodSpecEqual(lhs, rhs corev1.PodSpec, strictTolerations bool) bool {
 			// can't use reflect.DeepEqual here, due to k8s adding token mounts
 			// check that rContainer is all found within lContainer and that they match by name
 			if !comparators.ContainsSameVolumeMounts(lContainer.VolumeMounts, rContainer.VolumeMounts) {
-				equal = false
+				return false
 			}
 
 			if lContainer.Image != rContainer.Image {
-				equal = false
+				return false
+			}
+
+			if !reflect.DeepEqual(lContainer.Command, rContainer.Command) {
+				return false
 			}
 
 			if !comparators.EnvValueEqual(lContainer.Env, rContainer.Env) {
-				equal = false
+				return false
 			}
 
 			if !reflect.DeepEqual(lContainer.Args, rContainer.Args) {
-				equal = false
+				return false
 			}
 
 			if !reflect.DeepEqual(lContainer.Ports, rContainer.Ports) {
-				equal = false
+				return false
 			}
 
 			if !comparators.AreResourceRequementsSame(lContainer.Resources, rContainer.Resources) {
-				equal = false
+				return false
 			}
 		}
 
 		if !found {
-			equal = false
+			return false
 		}
 	}
 
-	return equal
+	return true
 }
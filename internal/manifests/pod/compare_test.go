@@ -7,6 +7,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 func TestArePodTemplateSpecEqual(t *testing.T) {
@@ -507,6 +509,147 @@ func TestArePodTemplateSpecEqual(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			desc: "added volume",
+			lhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			rhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{defaultContainer},
+					Volumes:    []corev1.Volume{{Name: "trust-bundle"}},
+				},
+			},
+			want: false,
+		},
+		{
+			desc: "added init container",
+			lhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			rhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers:     []corev1.Container{defaultContainer},
+					InitContainers: []corev1.Container{defaultContainer},
+				},
+			},
+			want: false,
+		},
+		{
+			desc: "different init container image",
+			lhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers:     []corev1.Container{defaultContainer},
+					InitContainers: []corev1.Container{defaultContainer},
+				},
+			},
+			rhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{defaultContainer},
+					InitContainers: []corev1.Container{
+						diffContainer(func(c *corev1.Container) {
+							c.Image = "other"
+						}),
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			desc: "different container command",
+			lhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			rhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						diffContainer(func(c *corev1.Container) {
+							c.Command = []string{"fluent-bit"}
+						}),
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			desc: "label change",
+			lhs: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "a"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			rhs: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "b"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			want: false,
+		},
+		{
+			desc: "added annotation",
+			lhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			rhs: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"backup": "enabled"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			want: false,
+		},
+		{
+			desc: "annotation added by others",
+			lhs: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"kubectl.kubernetes.io/restartedAt": "now"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			rhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			want: true,
+		},
+		{
+			desc: "priority class change",
+			lhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			rhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers:        []corev1.Container{defaultContainer},
+					PriorityClassName: "stateful-critical",
+				},
+			},
+			want: false,
+		},
+		{
+			desc: "affinity change",
+			lhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			rhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{defaultContainer},
+					Affinity:   &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{}},
+				},
+			},
+			want: false,
+		},
+		{
+			desc: "topology spread constraints change",
+			lhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{defaultContainer}},
+			},
+			rhs: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{defaultContainer},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+						{
+							MaxSkew:           1,
+							TopologyKey:       corev1.LabelTopologyZone,
+							WhenUnsatisfiable: corev1.ScheduleAnyway,
+						},
+					},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -722,6 +865,34 @@ func TestPodSpecEqual_NonStrictTolerations(t *testing.T) {
 			strict: false,
 			want:   true,
 		},
+		{
+			desc:   "non strict; priority class set by admission",
+			lhs:    corev1.PodSpec{PriorityClassName: "cluster-default", Priority: pointer.Int32(1000)},
+			rhs:    corev1.PodSpec{},
+			strict: false,
+			want:   true,
+		},
+		{
+			desc:   "strict; priority class not in rhs",
+			lhs:    corev1.PodSpec{PriorityClassName: "cluster-default"},
+			rhs:    corev1.PodSpec{},
+			strict: true,
+			want:   false,
+		},
+		{
+			desc:   "non strict; priority class of rhs differs",
+			lhs:    corev1.PodSpec{PriorityClassName: "cluster-default"},
+			rhs:    corev1.PodSpec{PriorityClassName: "logging-critical"},
+			strict: false,
+			want:   false,
+		},
+		{
+			desc:   "non strict; affinity of rhs missing",
+			lhs:    corev1.PodSpec{},
+			rhs:    corev1.PodSpec{Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{}}},
+			strict: false,
+			want:   false,
+		},
 	}
 	for _, test := range tests {
 		test := test
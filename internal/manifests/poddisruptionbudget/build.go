@@ -0,0 +1,49 @@
+package poddisruptionbudget
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Builder represents the struct to build k8s poddisruptionbudgets
+type Builder struct {
+	pdb *policyv1.PodDisruptionBudget
+}
+
+// New returns a new Builder instance with a default initialized poddisruptionbudget.
+func New(name, namespace string, labels map[string]string) *Builder {
+	return &Builder{pdb: newPodDisruptionBudget(name, namespace, labels)}
+}
+
+func newPodDisruptionBudget(name, namespace string, labels map[string]string) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{},
+	}
+}
+
+// Build returns the final poddisruptionbudget.
+func (b *Builder) Build() *policyv1.PodDisruptionBudget { return b.pdb }
+
+// WithSelector sets the label selector of the pods covered by the budget.
+func (b *Builder) WithSelector(s map[string]string) *Builder {
+	b.pdb.Spec.Selector = &metav1.LabelSelector{MatchLabels: s}
+	return b
+}
+
+// WithMaxUnavailable sets the number of pods that can be unavailable after an eviction.
+func (b *Builder) WithMaxUnavailable(n int32) *Builder {
+	val := intstr.FromInt(int(n))
+	b.pdb.Spec.MaxUnavailable = &val
+	b.pdb.Spec.MinAvailable = nil
+	return b
+}
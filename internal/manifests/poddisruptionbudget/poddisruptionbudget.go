@@ -0,0 +1,106 @@
+package poddisruptionbudget
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EqualityFunc is the type for functions that compare two poddisruptionbudgets.
+// Return true if two poddisruptionbudgets are equal.
+type EqualityFunc func(current, desired *policyv1.PodDisruptionBudget) bool
+
+// MutateFunc is the type for functions that mutate the current poddisruptionbudget
+// by applying the values from the desired poddisruptionbudget.
+type MutateFunc func(current, desired *policyv1.PodDisruptionBudget)
+
+// CreateOrUpdate attempts first to get the given poddisruptionbudget. If the
+// poddisruptionbudget does not exist, the poddisruptionbudget will be created. Otherwise,
+// if the poddisruptionbudget exists and the provided comparison func detects any changes
+// an update is attempted. Updates are retried with backoff (See retry.DefaultRetry).
+// Returns on failure an non-nil error.
+func CreateOrUpdate(ctx context.Context, c client.Client, pdb *policyv1.PodDisruptionBudget, equal EqualityFunc, mutate MutateFunc) error {
+	current := &policyv1.PodDisruptionBudget{}
+	key := client.ObjectKey{Name: pdb.Name, Namespace: pdb.Namespace}
+	err := c.Get(ctx, key, current)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			err = c.Create(ctx, pdb)
+
+			if err == nil {
+				return nil
+			}
+
+			return kverrors.Wrap(err, "failed to create poddisruptionbudget",
+				"name", pdb.Name,
+				"namespace", pdb.Namespace,
+			)
+		}
+
+		return kverrors.Wrap(err, "failed to get poddisruptionbudget",
+			"name", pdb.Name,
+			"namespace", pdb.Namespace,
+		)
+	}
+
+	if !equal(current, pdb) {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := c.Get(ctx, key, current); err != nil {
+				return kverrors.Wrap(err, "failed to get poddisruptionbudget",
+					"name", pdb.Name,
+					"namespace", pdb.Namespace,
+				)
+			}
+
+			mutate(current, pdb)
+			if err := c.Update(ctx, current); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return kverrors.Wrap(err, "failed to update poddisruptionbudget",
+				"name", pdb.Name,
+				"namespace", pdb.Namespace,
+			)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// Delete attempts to delete a k8s poddisruptionbudget if existing or returns an error.
+// A poddisruptionbudget that does not exist is not considered an error.
+func Delete(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	pdb := New(key.Name, key.Namespace, nil).Build()
+
+	if err := c.Delete(ctx, pdb, &client.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return kverrors.Wrap(err, "failed to delete poddisruptionbudget",
+			"name", pdb.Name,
+			"namespace", pdb.Namespace,
+		)
+	}
+
+	return nil
+}
+
+// Equal returns true only if the labels and the spec of the poddisruptionbudgets are equal.
+func Equal(current, desired *policyv1.PodDisruptionBudget) bool {
+	return equality.Semantic.DeepEqual(current.Labels, desired.Labels) &&
+		equality.Semantic.DeepEqual(current.Spec, desired.Spec)
+}
+
+// Mutate is a default mutation function for poddisruptionbudgets
+// that copies only mutable fields from desired to current.
+func Mutate(current, desired *policyv1.PodDisruptionBudget) {
+	current.Labels = desired.Labels
+	current.Spec.Selector = desired.Spec.Selector
+	current.Spec.MinAvailable = desired.Spec.MinAvailable
+	current.Spec.MaxUnavailable = desired.Spec.MaxUnavailable
+}
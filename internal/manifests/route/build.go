@@ -45,6 +45,27 @@ func (b *Builder) WithTLSConfig(tc *routev1.TLSConfig) *Builder {
 	return b
 }
 
+// WithHost sets the host name of the route
+func (b *Builder) WithHost(host string) *Builder {
+	b.r.Spec.Host = host
+	return b
+}
+
+// WithAnnotations sets the annotations of the route and records their keys
+// under AppliedAnnotationsKey
+func (b *Builder) WithAnnotations(a map[string]string) *Builder {
+	if len(a) == 0 {
+		b.r.Annotations = nil
+		return b
+	}
+
+	b.r.Annotations = map[string]string{AppliedAnnotationsKey: appliedAnnotations(a)}
+	for k, v := range a {
+		b.r.Annotations[k] = v
+	}
+	return b
+}
+
 // WithCA sets the certificate authority to the TLS config if present
 func (b *Builder) WithCA(caCert []byte) *Builder {
 	if b.r.Spec.TLS != nil {
@@ -2,15 +2,26 @@ package route
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/ViaQ/logerr/v2/kverrors"
 	routev1 "github.com/openshift/api/route/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// AppliedAnnotationsKey is the annotation listing the keys of the annotations applied
+// from the spec, so they are removed once they are dropped from the spec while the
+// annotations set by others are kept
+const AppliedAnnotationsKey = "logging.openshift.io/applied-annotations"
+
+// HostGeneratedAnnotation is set by the API server on routes whose host it generated
+const HostGeneratedAnnotation = "openshift.io/host.generated"
+
 // EqualityFunc is the type for functions that compare two routes.
 // Return true if two route are equal.
 type EqualityFunc func(current, desired *routev1.Route) bool
@@ -89,6 +100,29 @@ func CreateOrUpdate(ctx context.Context, c client.Client, r *routev1.Route, equa
 	return nil
 }
 
+// Delete attempts to delete an openshift route if existing or returns an error.
+// A route that does not exist is not considered an error.
+func Delete(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	r := New(key.Name, key.Namespace, "", nil).Build()
+
+	if err := c.Delete(ctx, r, &client.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return kverrors.Wrap(err, "failed to delete route",
+			"name", r.Name,
+			"namespace", r.Namespace,
+		)
+	}
+
+	return nil
+}
+
+// RouteEnabled returns false only if the openshift route API is not available on the cluster.
+func RouteEnabled(ctx context.Context, c client.Client, namespace string) bool {
+	routes := &routev1.RouteList{}
+	err := c.List(ctx, routes, client.InNamespace(namespace), client.Limit(1))
+
+	return err == nil || !meta.IsNoMatchError(err)
+}
+
 // RouteTLSConfigEqual returns true only if the routes are equal in tls configs.
 func RouteTLSConfigEqual(current, desired *routev1.Route) bool {
 	return equality.Semantic.DeepEqual(current.Spec.TLS, desired.Spec.TLS)
@@ -99,3 +133,74 @@ func RouteTLSConfigEqual(current, desired *routev1.Route) bool {
 func MutateTLSConfigOnly(current, desired *routev1.Route) {
 	current.Spec.TLS = desired.Spec.TLS
 }
+
+// RouteHostAndTLSConfigEqual returns true only if the routes are equal in labels, tls configs
+// and hosts, and the current route has the desired annotations. An empty desired host matches
+// the host generated by the router.
+func RouteHostAndTLSConfigEqual(current, desired *routev1.Route) bool {
+	if desired.Spec.Host != "" && current.Spec.Host != desired.Spec.Host {
+		return false
+	}
+
+	for k, v := range desired.Annotations {
+		if current.Annotations[k] != v {
+			return false
+		}
+	}
+	if current.Annotations[AppliedAnnotationsKey] != appliedAnnotations(desired.Annotations) {
+		return false
+	}
+
+	return equality.Semantic.DeepEqual(current.Labels, desired.Labels) &&
+		RouteTLSConfigEqual(current, desired)
+}
+
+// MutateHostAndTLSConfig is a mutate implementation that copies the labels, the annotations,
+// the host if set and the tls config from desired to current. The annotations previously
+// applied from desired and missing from it now are removed.
+func MutateHostAndTLSConfig(current, desired *routev1.Route) {
+	current.Labels = desired.Labels
+	if current.Annotations == nil {
+		current.Annotations = map[string]string{}
+	}
+	if applied := current.Annotations[AppliedAnnotationsKey]; applied != "" {
+		for _, k := range strings.Split(applied, ",") {
+			if _, ok := desired.Annotations[k]; !ok {
+				delete(current.Annotations, k)
+			}
+		}
+	}
+	for k, v := range desired.Annotations {
+		current.Annotations[k] = v
+	}
+	if applied := appliedAnnotations(desired.Annotations); applied != "" {
+		current.Annotations[AppliedAnnotationsKey] = applied
+	} else {
+		delete(current.Annotations, AppliedAnnotationsKey)
+	}
+	if desired.Spec.Host != "" {
+		current.Spec.Host = desired.Spec.Host
+	}
+	current.Spec.TLS = desired.Spec.TLS
+}
+
+// CustomHostDropped returns true only if the desired route has no host while the current
+// route has one that was not generated. The API server keeps the host when an update clears
+// it, so the route needs to be recreated to get a generated host again.
+func CustomHostDropped(current, desired *routev1.Route) bool {
+	return desired.Spec.Host == "" && current.Spec.Host != "" &&
+		current.Annotations[HostGeneratedAnnotation] != "true"
+}
+
+// appliedAnnotations returns the sorted keys of the annotations as value of AppliedAnnotationsKey
+func appliedAnnotations(annotations map[string]string) string {
+	keys := make([]string, 0, len(annotations))
+	for k := range annotations {
+		if k != AppliedAnnotationsKey {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return strings.Join(keys, ",")
+}
@@ -68,6 +68,13 @@ var (
 		}, []string{"policy"},
 	)
 
+	emergencyIndexDeletionMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "eo_es_emergency_index_deletions_total",
+			Help: "Number of indices deleted by the emergency retention policy",
+		}, []string{"alias"},
+	)
+
 	memoryConfigurationMetric = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "eo_es_misconfigured_memory_resources_info",
@@ -86,6 +93,7 @@ func RegisterCustomMetrics() {
 		documentAgeMetric,
 		deleteNamespaceMetric,
 		memoryConfigurationMetric,
+		emergencyIndexDeletionMetric,
 	}
 
 	for _, metric := range metricCollectors {
@@ -125,6 +133,13 @@ func IncrementRestartCounterScheduled() {
 	}).Inc()
 }
 
+// Increment the metric value by "1" when an index of the alias is deleted by the emergency retention policy.
+func IncrementEmergencyIndexDeletions(alias string) {
+	emergencyIndexDeletionMetric.With(prometheus.Labels{
+		"alias": alias,
+	}).Inc()
+}
+
 // Sets the metric value with the number of seconds that a document
 // is retained for in a given index for a rollover or delete operation.
 func SetIndexRetentionDocumentAge(isDeleteOp bool, mapping string, seconds uint64) {
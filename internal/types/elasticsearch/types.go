@@ -72,6 +72,29 @@ type IndexTemplateSettings struct {
 	RefreshInterval  string                 `json:"refresh_interval,omitempty"`
 	NumberOfShards   string                 `json:"number_of_shards,omitempty"`
 	NumberOfReplicas string                 `json:"number_of_replicas,omitempty"`
+	Search           *SlowlogIndexSetting   `json:"search,omitempty"`
+	Indexing         *SlowlogIndexSetting   `json:"indexing,omitempty"`
+}
+
+type SlowlogIndexSetting struct {
+	Slowlog SlowlogSetting `json:"slowlog,omitempty"`
+}
+
+type SlowlogSetting struct {
+	Threshold SlowlogThresholdSetting `json:"threshold,omitempty"`
+}
+
+type SlowlogThresholdSetting struct {
+	Query *SlowlogLevelSetting `json:"query,omitempty"`
+	Fetch *SlowlogLevelSetting `json:"fetch,omitempty"`
+	Index *SlowlogLevelSetting `json:"index,omitempty"`
+}
+
+type SlowlogLevelSetting struct {
+	Warn  string `json:"warn,omitempty"`
+	Info  string `json:"info,omitempty"`
+	Debug string `json:"debug,omitempty"`
+	Trace string `json:"trace,omitempty"`
 }
 
 type UnassignedIndexSetting struct {
@@ -104,6 +127,8 @@ type IndexingSettings struct {
 	Blocks           *IndexBlocksSettings  `json:"blocks,omitempty"`
 	Mapper           *IndexMapperSettings  `json:"mapper,omitempty"`
 	Mapping          *IndexMappingSettings `json:"mapping,omitempty"`
+	Search           *SlowlogIndexSetting  `json:"search,omitempty"`
+	Indexing         *SlowlogIndexSetting  `json:"indexing,omitempty"`
 }
 
 type IndexBlocksSettings struct {
@@ -192,3 +217,12 @@ type StatsNode struct {
 	Versions []string       `json:"versions,omitempty"`
 	Count    map[string]int `json:"count,omitempty"`
 }
+
+type RemoteInfoResponse map[string]RemoteClusterInfo
+
+type RemoteClusterInfo struct {
+	Seeds             []string `json:"seeds,omitempty"`
+	Connected         bool     `json:"connected"`
+	NumNodesConnected int32    `json:"num_nodes_connected,omitempty"`
+	SkipUnavailable   bool     `json:"skip_unavailable,omitempty"`
+}
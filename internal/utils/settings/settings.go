@@ -0,0 +1,112 @@
+package settings
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	"gopkg.in/yaml.v2"
+)
+
+// KeyRegexp matches the well-formed setting names, dot separated words
+var KeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9_\-]+(\.[a-zA-Z0-9_\-]+)*$`)
+
+// Policy defines the settings of a custom resource which can be passed on to a component
+type Policy struct {
+	// Kind names the settings in error messages, e.g. "elasticsearch.yml setting"
+	Kind string
+	// Protected are the settings owned by the operator, including their children
+	Protected []string
+}
+
+// IsProtected returns true if the setting or any of its parents is owned by the operator
+func (p Policy) IsProtected(key string) bool {
+	for _, protected := range p.Protected {
+		if key == protected || strings.HasPrefix(key, protected+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate ensures that the settings contain only well-formed keys not owned by the operator
+func (p Policy) Validate(settings map[string]string) error {
+	for key := range settings {
+		if !KeyRegexp.MatchString(key) {
+			return kverrors.New(fmt.Sprintf("invalid %s name", p.Kind), "setting", key)
+		}
+
+		if p.IsProtected(key) {
+			return kverrors.New(fmt.Sprintf("%s is managed by the operator", p.Kind), "setting", key)
+		}
+	}
+
+	return nil
+}
+
+// Render writes the settings as yml, one line per setting sorted by key.
+// Invalid and protected settings are skipped, they are rejected by Validate.
+// Values are written as plain scalars to keep the type of numbers, booleans and
+// lists, and quoted only when yml would not read them back as written.
+func (p Policy) Render(w io.Writer, settings map[string]string) error {
+	for _, key := range SortedKeys(settings) {
+		if p.IsProtected(key) || !KeyRegexp.MatchString(key) {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "%s: %s\n", key, renderValue(key, settings[key])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderValue returns the value as plain yml scalar, or quoted when it is empty, spans
+// several lines, starts with an indicator character, holds a comment or mapping
+// separator or does not parse as the only value of the key
+func renderValue(key, value string) string {
+	if value == "" || value != strings.TrimSpace(value) || strings.ContainsAny(value, "\n\r") ||
+		strings.ContainsAny(value[:1], "!&*#|>%@`'\"") ||
+		strings.Contains(value, ": ") || strings.Contains(value, " #") {
+		return strconv.Quote(value)
+	}
+
+	parsed := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(fmt.Sprintf("%s: %s", key, value)), &parsed); err != nil || len(parsed) != 1 || parsed[key] == nil {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
+
+// Hash returns a hash of the settings which changes whenever a setting is added, removed or changed
+func Hash(settings map[string]string) string {
+	if len(settings) == 0 {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, key := range SortedKeys(settings) {
+		fmt.Fprintf(h, "%s=%s\n", key, settings[key])
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// SortedKeys returns the keys of the settings in ascending order
+func SortedKeys(settings map[string]string) []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,122 @@
+package settings
+
+import (
+	"strings"
+	"testing"
+)
+
+var testPolicy = Policy{
+	Kind:      "test setting",
+	Protected: []string{"path", "node.name"},
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings map[string]string
+		wantErr  bool
+	}{
+		{
+			desc:     "user settings",
+			settings: map[string]string{"indices.memory.index_buffer_size": "20%", "node.attr.zone": "zone-a"},
+		},
+		{
+			desc:     "protected setting",
+			settings: map[string]string{"node.name": "es"},
+			wantErr:  true,
+		},
+		{
+			desc:     "child of a protected setting",
+			settings: map[string]string{"path.data": "/tmp"},
+			wantErr:  true,
+		},
+		{
+			desc:     "malformed setting name",
+			settings: map[string]string{"search.max_buckets: 1\npath.data": "/tmp"},
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			err := testPolicy.Validate(test.settings)
+			if test.wantErr && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	settings := map[string]string{
+		"node.attr.zone":                   "zone-a",
+		"indices.memory.index_buffer_size": "20%",
+		"path.data":                        "/tmp",
+		"bad: key":                         "value",
+	}
+
+	var b strings.Builder
+	if err := testPolicy.Render(&b, settings); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := "indices.memory.index_buffer_size: 20%\nnode.attr.zone: zone-a\n"
+	if got := b.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRenderValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "1000", want: "1000"},
+		{value: "false", want: "false"},
+		{value: "20%", want: "20%"},
+		{value: "/app/discover", want: "/app/discover"},
+		{value: `["https://es:9200"]`, want: `["https://es:9200"]`},
+		{value: "", want: `""`},
+		{value: "null", want: `"null"`},
+		{value: " padded", want: `" padded"`},
+		{value: "a: b", want: `"a: b"`},
+		{value: "value # comment", want: `"value # comment"`},
+		{value: "*alias", want: `"*alias"`},
+		{value: "%invalid", want: `"%invalid"`},
+		{value: "- item", want: `"- item"`},
+		{value: "[unclosed", want: `"[unclosed"`},
+		{value: "line\nbreak", want: `"line\nbreak"`},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.value, func(t *testing.T) {
+			if got := renderValue("key", test.value); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	settings := map[string]string{"node.attr.zone": "zone-a"}
+
+	hash := Hash(settings)
+	if hash == "" {
+		t.Fatalf("expected a hash for the settings")
+	}
+	if Hash(map[string]string{"node.attr.zone": "zone-a"}) != hash {
+		t.Errorf("expected the same settings to have the same hash")
+	}
+	settings["node.attr.zone"] = "zone-b"
+	if Hash(settings) == hash {
+		t.Errorf("expected a changed setting to change the hash")
+	}
+	if Hash(nil) != "" {
+		t.Errorf("expected no hash without settings")
+	}
+}
@@ -134,6 +134,16 @@ func AddOwnerRefToObject(object metav1.Object, ownerRef metav1.OwnerReference) {
 	}
 }
 
+// HasOwnerRef returns true if the object carries the given owner reference
+func HasOwnerRef(object metav1.Object, ownerRef metav1.OwnerReference) bool {
+	for _, ref := range object.GetOwnerReferences() {
+		if ref.UID == ownerRef.UID && ref.Kind == ownerRef.Kind && ref.Name == ownerRef.Name {
+			return true
+		}
+	}
+	return false
+}
+
 func GetWorkingDirFilePath(toFile string) string {
 	workingDir := os.Getenv("WORKING_DIR")
 	if workingDir == "" {
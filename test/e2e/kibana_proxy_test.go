@@ -0,0 +1,176 @@
+package e2e
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/openshift/elasticsearch-operator/internal/kibana"
+	"github.com/openshift/elasticsearch-operator/test/utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	kibanaProxyImageEnv     = "KIBANA_PROXY_IMAGE"
+	defaultKibanaProxyImage = "quay.io/openshift/origin-oauth-proxy:latest"
+
+	// proxyStableInterval is how long the proxy must keep running without restart
+	proxyStableInterval = 30 * time.Second
+)
+
+// TestKibanaBasicAuthProxy starts the proxy with the arguments used where the OpenShift
+// OAuth server is not available and checks that it keeps running
+func TestKibanaBasicAuthProxy(t *testing.T) {
+	setupK8sClient(t)
+
+	image := os.Getenv(kibanaProxyImageEnv)
+	if image == "" {
+		image = defaultKibanaProxyImage
+	}
+	t.Logf("Using proxy image: %q", image)
+
+	name := fmt.Sprintf("kibana-basic-auth-%s", utils.GenerateUUID())
+
+	cert, key, err := selfSignedCertificate(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxySecret := utils.Secret(name, operatorNamespace, map[string][]byte{
+		"session-secret": []byte(utils.GenerateUUID()),
+		"server-cert":    cert,
+		"server-key":     key,
+	})
+	authSecret := utils.Secret(name+"-auth", operatorNamespace, map[string][]byte{
+		"auth": htpasswdEntry("admin", "changeme"),
+	})
+
+	labels := map[string]string{"app": name}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: operatorNamespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyAlways,
+			Containers: []corev1.Container{
+				{
+					Name:  "kibana-proxy",
+					Image: image,
+					Args:  kibana.BasicAuthProxyArgs(24 * time.Hour),
+					Ports: []corev1.ContainerPort{
+						{Name: "oaproxy", ContainerPort: 3000},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "kibana-proxy", MountPath: "/secret", ReadOnly: true},
+						{Name: "kibana-basic-auth", MountPath: "/etc/proxy/htpasswd", ReadOnly: true},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "kibana-proxy",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: proxySecret.Name},
+					},
+				},
+				{
+					Name: "kibana-basic-auth",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: authSecret.Name},
+					},
+				},
+			},
+		},
+	}
+
+	for _, obj := range []client.Object{proxySecret, authSecret, pod} {
+		obj := obj
+		if err := k8sClient.Create(context.TODO(), obj); err != nil {
+			t.Fatalf("failed to create %s: %s", obj.GetName(), err)
+		}
+		t.Cleanup(func() {
+			if err := k8sClient.Delete(context.TODO(), obj); err != nil {
+				t.Logf("failed to delete %s: %s", obj.GetName(), err)
+			}
+		})
+	}
+
+	// wait for the proxy to start, then make sure it does not exit afterwards
+	var runningSince time.Time
+	err = wait.Poll(retryInterval, timeout, func() (bool, error) {
+		current := &corev1.Pod{}
+		if err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(pod), current); err != nil {
+			return false, err
+		}
+		if len(current.Status.ContainerStatuses) == 0 {
+			return false, nil
+		}
+		status := current.Status.ContainerStatuses[0]
+		if status.RestartCount > 0 || status.State.Terminated != nil {
+			return false, fmt.Errorf("proxy container exited: %+v", status.LastTerminationState)
+		}
+		if status.State.Running == nil {
+			t.Logf("Waiting for the proxy container to run: %+v", status.State)
+			return false, nil
+		}
+		if runningSince.IsZero() {
+			runningSince = time.Now()
+		}
+		return time.Since(runningSince) >= proxyStableInterval, nil
+	})
+	if err != nil {
+		t.Fatalf("proxy did not keep running: %s", err)
+	}
+}
+
+// selfSignedCertificate returns the PEM encoded certificate and key serving the proxy
+func selfSignedCertificate(commonName string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// htpasswdEntry returns a htpasswd line in the SHA format understood by the proxy
+func htpasswdEntry(user, password string) []byte {
+	sum := sha1.Sum([]byte(password))
+	return []byte(fmt.Sprintf("%s:{SHA}%s\n", user, base64.StdEncoding.EncodeToString(sum[:])))
+}